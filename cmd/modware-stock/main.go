@@ -3,6 +3,7 @@ package main
 import (
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/dictyBase/aphgrpc"
 	arango "github.com/dictyBase/arangomanager/command/flag"
@@ -37,6 +38,34 @@ func main() {
 			Before: validate.ValidateServerArgs,
 			Flags:  allFlags(),
 		},
+		{
+			Name:   "purge-stocks",
+			Usage:  "permanently removes stocks that were deleted earlier",
+			Action: server.RunPurge,
+			Before: validate.ValidateDbArgs,
+			Flags:  purgeFlags(),
+		},
+		{
+			Name:   "backfill-revisions",
+			Usage:  "records a baseline revision of the stocks without any, so that they could be read as of a time",
+			Action: server.RunBackfill,
+			Before: validate.ValidateDbArgs,
+			Flags:  backfillFlags(),
+		},
+		{
+			Name:   "audit-parents",
+			Usage:  "reports the strains that are their own ancestors through cycles of parents",
+			Action: server.RunParentAudit,
+			Before: validate.ValidateDbArgs,
+			Flags:  dbFlags(),
+		},
+		{
+			Name:   "export-lineage",
+			Usage:  "exports the ancestor or the descendant tree of a strain as dot, newick or json",
+			Action: server.RunLineageExport,
+			Before: validate.ValidateLineageExportArgs,
+			Flags:  lineageFlags(),
+		},
 	}
 	if err := app.Run(os.Args); err != nil {
 		fmt.Printf("error in running the app %s", err)
@@ -47,6 +76,12 @@ func main() {
 func allFlags() []cli.Flag {
 	f := make([]cli.Flag, 0)
 	f = append(f, serverFlags()...)
+	f = append(f, dbFlags()...)
+	return append(f, aphgrpc.NatsFlag()...)
+}
+
+func dbFlags() []cli.Flag {
+	f := make([]cli.Flag, 0)
 	f = append(f, dbCollectionFlags()...)
 	f = append(f, arango.ArangoFlags()...)
 	f = append(f, []cli.Flag{
@@ -56,9 +91,77 @@ func allFlags() []cli.Flag {
 			Usage:  "arangodb database name",
 			Value:  "stock",
 		},
+		cli.IntFlag{
+			Name:  "keyoffset",
+			Usage: "initial offset for stock id generation",
+			Value: 370000,
+		},
+		cli.StringFlag{
+			Name:  "strain-ontology",
+			Usage: "dictybase ontology that will be used for picking grouping term for strain",
+			Value: "dicty_strain_property",
+		},
+		cli.DurationFlag{
+			Name:  "lock-timeout",
+			Usage: "time a write transaction waits for acquiring its collection locks",
+			Value: 30 * time.Second,
+		},
+	}...)
+	return append(f, oboflag.OntologyFlagsOnly()...)
+}
+
+func purgeFlags() []cli.Flag {
+	return append(dbFlags(), []cli.Flag{
+		cli.DurationFlag{
+			Name:  "older-than",
+			Usage: "minimum time elapsed since deletion for a stock to be purged",
+			Value: 30 * 24 * time.Hour,
+		},
+		cli.StringFlag{
+			Name:  "purged-by",
+			Usage: "user that the purges are recorded as made by",
+			Value: "modware-stock",
+		},
+	}...)
+}
+
+func backfillFlags() []cli.Flag {
+	return append(dbFlags(), cli.StringFlag{
+		Name:  "backfilled-by",
+		Usage: "user that the baseline revisions are recorded as made by",
+		Value: "modware-stock",
+	})
+}
+
+func lineageFlags() []cli.Flag {
+	return append(dbFlags(), []cli.Flag{
+		cli.StringFlag{
+			Name:  "id",
+			Usage: "id of the strain the lineage starts from",
+		},
+		cli.StringFlag{
+			Name:  "direction",
+			Usage: "direction of the lineage, either of ancestors or descendants",
+			Value: "descendants",
+		},
+		cli.IntFlag{
+			Name:  "depth",
+			Usage: "largest number of generations to traverse, zero for all of them",
+		},
+		cli.StringSliceFlag{
+			Name:  "relationship",
+			Usage: "relationship of the parents to follow, could be repeated, all of them are followed without any",
+		},
+		cli.StringFlag{
+			Name:  "format",
+			Usage: "format of the exported lineage, either of dot, newick or json",
+			Value: "dot",
+		},
+		cli.StringFlag{
+			Name:  "output",
+			Usage: "file to write the exported lineage to, the standard output without any",
+		},
 	}...)
-	f = append(f, oboflag.OntologyFlagsOnly()...)
-	return append(f, aphgrpc.NatsFlag()...)
 }
 
 func serverFlags() []cli.Flag {
@@ -68,25 +171,35 @@ func serverFlags() []cli.Flag {
 			Usage: "tcp port at which the server will be available",
 			Value: "9560",
 		},
-		cli.IntFlag{
-			Name:  "keyoffset",
-			Usage: "initial offset for stock id generation",
-			Value: 370000,
-		},
 		cli.BoolTFlag{
 			Name:  "reflection, ref",
 			Usage: "flag for enabling server reflection",
 		},
-		cli.StringFlag{
-			Name:  "strain-ontology",
-			Usage: "dictybase ontology that will be used for picking grouping term for strain",
-			Value: "dicty_strain_property",
-		},
 		cli.StringFlag{
 			Name:  "strain-term",
 			Usage: "default ontology term that will be used for creating strain",
 			Value: "general strain",
 		},
+		cli.DurationFlag{
+			Name:  "get-timeout",
+			Usage: "time limit for retrieving a single stock, zero for no limit",
+			Value: 10 * time.Second,
+		},
+		cli.DurationFlag{
+			Name:  "list-timeout",
+			Usage: "time limit for listing stocks, zero for no limit",
+			Value: 30 * time.Second,
+		},
+		cli.DurationFlag{
+			Name:  "write-timeout",
+			Usage: "time limit for creating, updating or removing stocks, zero for no limit",
+			Value: 30 * time.Second,
+		},
+		cli.Int64Flag{
+			Name:  "max-page-size",
+			Usage: "largest number of stocks listed in a single page, zero for no limit",
+			Value: 100,
+		},
 	}
 }
 
@@ -107,6 +220,11 @@ func dbCollectionFlags() []cli.Flag {
 			Usage: "arangodb collection for generating unique IDs",
 			Value: "stock_key_generator",
 		},
+		cli.StringFlag{
+			Name:  "stock-revision-collection",
+			Usage: "arangodb collection for storing the change history of stocks",
+			Value: "stock_revision",
+		},
 		cli.StringFlag{
 			Name:  "stock-type-edge",
 			Usage: "arangodb edge collection for connecting stocks to their types (strain or plasmid)",
@@ -132,6 +250,11 @@ func dbCollectionFlags() []cli.Flag {
 			Usage: "arangodb named graph for managing relations between strains and their parents",
 			Value: "strain2parent",
 		},
+		cli.StringFlag{
+			Name:  "search-view",
+			Usage: "arangodb arangosearch view for the full text search of stocks",
+			Value: "stock_search",
+		},
 		cli.StringFlag{
 			Name:  "stockonto-graph",
 			Usage: "arangodb named graph for managing stock and ontology",
@@ -0,0 +1,6 @@
+// Package stockext has the generated protocol buffer and gRPC code of the
+// stock operations that are not part of the published StockService of
+// dictybaseapis.
+package stockext
+
+//go:generate protoc -I . -I ${DICTYBASEAPIS} --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative stockext.proto
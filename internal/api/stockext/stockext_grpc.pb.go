@@ -0,0 +1,798 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: stockext.proto
+
+package stockext
+
+import (
+	context "context"
+	stock "github.com/dictyBase/go-genproto/dictybaseapis/stock"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	StockExtensionService_RestoreStock_FullMethodName         = "/dictybase.stockext.StockExtensionService/RestoreStock"
+	StockExtensionService_ListDeletedStocks_FullMethodName    = "/dictybase.stockext.StockExtensionService/ListDeletedStocks"
+	StockExtensionService_PurgeStock_FullMethodName           = "/dictybase.stockext.StockExtensionService/PurgeStock"
+	StockExtensionService_GetStockHistory_FullMethodName      = "/dictybase.stockext.StockExtensionService/GetStockHistory"
+	StockExtensionService_GetStrainAsOf_FullMethodName        = "/dictybase.stockext.StockExtensionService/GetStrainAsOf"
+	StockExtensionService_GetPlasmidAsOf_FullMethodName       = "/dictybase.stockext.StockExtensionService/GetPlasmidAsOf"
+	StockExtensionService_ListStrainsAsOf_FullMethodName      = "/dictybase.stockext.StockExtensionService/ListStrainsAsOf"
+	StockExtensionService_RevertStock_FullMethodName          = "/dictybase.stockext.StockExtensionService/RevertStock"
+	StockExtensionService_SearchStocks_FullMethodName         = "/dictybase.stockext.StockExtensionService/SearchStocks"
+	StockExtensionService_AutocompleteStocks_FullMethodName   = "/dictybase.stockext.StockExtensionService/AutocompleteStocks"
+	StockExtensionService_ListFilterableFields_FullMethodName = "/dictybase.stockext.StockExtensionService/ListFilterableFields"
+	StockExtensionService_GetStrainFacets_FullMethodName      = "/dictybase.stockext.StockExtensionService/GetStrainFacets"
+	StockExtensionService_CountStrains_FullMethodName         = "/dictybase.stockext.StockExtensionService/CountStrains"
+	StockExtensionService_CountPlasmids_FullMethodName        = "/dictybase.stockext.StockExtensionService/CountPlasmids"
+	StockExtensionService_SyncStocks_FullMethodName           = "/dictybase.stockext.StockExtensionService/SyncStocks"
+	StockExtensionService_GetStrainAncestors_FullMethodName   = "/dictybase.stockext.StockExtensionService/GetStrainAncestors"
+	StockExtensionService_GetStrainDescendants_FullMethodName = "/dictybase.stockext.StockExtensionService/GetStrainDescendants"
+	StockExtensionService_ExportStrainLineage_FullMethodName  = "/dictybase.stockext.StockExtensionService/ExportStrainLineage"
+)
+
+// StockExtensionServiceClient is the client API for StockExtensionService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type StockExtensionServiceClient interface {
+	// RestoreStock brings back a stock removed by RemoveStock
+	RestoreStock(ctx context.Context, in *stock.StockId, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// ListDeletedStocks lists the removed stocks, the most recently removed
+	// one first
+	ListDeletedStocks(ctx context.Context, in *DeletedStockParameters, opts ...grpc.CallOption) (*DeletedStockCollection, error)
+	// PurgeStock permanently removes a stock that was removed by
+	// RemoveStock, a stock that is not removed is refused
+	PurgeStock(ctx context.Context, in *PurgeStockRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// GetStockHistory lists the revisions of a stock, the most recent one
+	// first
+	GetStockHistory(ctx context.Context, in *StockHistoryParameters, opts ...grpc.CallOption) (*StockRevisionCollection, error)
+	// GetStrainAsOf gets a strain as it existed at a point in time
+	GetStrainAsOf(ctx context.Context, in *StockIdAsOf, opts ...grpc.CallOption) (*stock.Strain, error)
+	// GetPlasmidAsOf gets a plasmid as it existed at a point in time
+	GetPlasmidAsOf(ctx context.Context, in *StockIdAsOf, opts ...grpc.CallOption) (*stock.Plasmid, error)
+	// ListStrainsAsOf lists strains as they existed at a point in time
+	ListStrainsAsOf(ctx context.Context, in *StockParametersAsOf, opts ...grpc.CallOption) (*stock.StrainCollection, error)
+	// RevertStock resets a stock to its state right after a revision
+	RevertStock(ctx context.Context, in *StockRevertParameters, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// SearchStocks finds the stocks whose summary, genes, label, names or
+	// plasmid name match the words of the query, the most relevant first
+	SearchStocks(ctx context.Context, in *StockSearchParameters, opts ...grpc.CallOption) (*StockSearchResult, error)
+	// AutocompleteStocks suggests the stocks whose label, names or plasmid
+	// name start with or resemble the typed text, the closest match first
+	AutocompleteStocks(ctx context.Context, in *StockAutocompleteParameters, opts ...grpc.CallOption) (*StockSuggestionCollection, error)
+	// ListFilterableFields gives the fields that the list of a stock type
+	// could be filtered by, along with their type and operators
+	ListFilterableFields(ctx context.Context, in *FilterableFieldParameters, opts ...grpc.CallOption) (*FilterableFieldCollection, error)
+	// GetStrainFacets counts the strains that match a filter by every
+	// distinct value of the facet fields
+	GetStrainFacets(ctx context.Context, in *StrainFacetParameters, opts ...grpc.CallOption) (*StrainFacetCollection, error)
+	// CountStrains counts the strains that match a filter
+	CountStrains(ctx context.Context, in *StockCountParameters, opts ...grpc.CallOption) (*StockCount, error)
+	// CountPlasmids counts the plasmids that match a filter
+	CountPlasmids(ctx context.Context, in *StockCountParameters, opts ...grpc.CallOption) (*StockCount, error)
+	// SyncStocks lists the strains and plasmids that were created, updated
+	// or removed since a watermark, the earliest change first
+	SyncStocks(ctx context.Context, in *StockSyncParameters, opts ...grpc.CallOption) (*StockChangeCollection, error)
+	// GetStrainAncestors lists the parents of a strain, their parents and
+	// so on, the nearest generation first
+	GetStrainAncestors(ctx context.Context, in *StrainLineageParameters, opts ...grpc.CallOption) (*StrainRelativeCollection, error)
+	// GetStrainDescendants lists the strains derived from a strain, the
+	// ones derived from them and so on, the nearest generation first
+	GetStrainDescendants(ctx context.Context, in *StrainLineageParameters, opts ...grpc.CallOption) (*StrainRelativeCollection, error)
+	// ExportStrainLineage exports the ancestor or the descendant tree of a
+	// strain as a Graphviz DOT graph, a Newick tree or a nested JSON tree
+	ExportStrainLineage(ctx context.Context, in *StrainLineageExportParameters, opts ...grpc.CallOption) (*StrainLineageExport, error)
+}
+
+type stockExtensionServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewStockExtensionServiceClient(cc grpc.ClientConnInterface) StockExtensionServiceClient {
+	return &stockExtensionServiceClient{cc}
+}
+
+func (c *stockExtensionServiceClient) RestoreStock(ctx context.Context, in *stock.StockId, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, StockExtensionService_RestoreStock_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stockExtensionServiceClient) ListDeletedStocks(ctx context.Context, in *DeletedStockParameters, opts ...grpc.CallOption) (*DeletedStockCollection, error) {
+	out := new(DeletedStockCollection)
+	err := c.cc.Invoke(ctx, StockExtensionService_ListDeletedStocks_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stockExtensionServiceClient) PurgeStock(ctx context.Context, in *PurgeStockRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, StockExtensionService_PurgeStock_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stockExtensionServiceClient) GetStockHistory(ctx context.Context, in *StockHistoryParameters, opts ...grpc.CallOption) (*StockRevisionCollection, error) {
+	out := new(StockRevisionCollection)
+	err := c.cc.Invoke(ctx, StockExtensionService_GetStockHistory_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stockExtensionServiceClient) GetStrainAsOf(ctx context.Context, in *StockIdAsOf, opts ...grpc.CallOption) (*stock.Strain, error) {
+	out := new(stock.Strain)
+	err := c.cc.Invoke(ctx, StockExtensionService_GetStrainAsOf_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stockExtensionServiceClient) GetPlasmidAsOf(ctx context.Context, in *StockIdAsOf, opts ...grpc.CallOption) (*stock.Plasmid, error) {
+	out := new(stock.Plasmid)
+	err := c.cc.Invoke(ctx, StockExtensionService_GetPlasmidAsOf_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stockExtensionServiceClient) ListStrainsAsOf(ctx context.Context, in *StockParametersAsOf, opts ...grpc.CallOption) (*stock.StrainCollection, error) {
+	out := new(stock.StrainCollection)
+	err := c.cc.Invoke(ctx, StockExtensionService_ListStrainsAsOf_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stockExtensionServiceClient) RevertStock(ctx context.Context, in *StockRevertParameters, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, StockExtensionService_RevertStock_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stockExtensionServiceClient) SearchStocks(ctx context.Context, in *StockSearchParameters, opts ...grpc.CallOption) (*StockSearchResult, error) {
+	out := new(StockSearchResult)
+	err := c.cc.Invoke(ctx, StockExtensionService_SearchStocks_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stockExtensionServiceClient) AutocompleteStocks(ctx context.Context, in *StockAutocompleteParameters, opts ...grpc.CallOption) (*StockSuggestionCollection, error) {
+	out := new(StockSuggestionCollection)
+	err := c.cc.Invoke(ctx, StockExtensionService_AutocompleteStocks_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stockExtensionServiceClient) ListFilterableFields(ctx context.Context, in *FilterableFieldParameters, opts ...grpc.CallOption) (*FilterableFieldCollection, error) {
+	out := new(FilterableFieldCollection)
+	err := c.cc.Invoke(ctx, StockExtensionService_ListFilterableFields_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stockExtensionServiceClient) GetStrainFacets(ctx context.Context, in *StrainFacetParameters, opts ...grpc.CallOption) (*StrainFacetCollection, error) {
+	out := new(StrainFacetCollection)
+	err := c.cc.Invoke(ctx, StockExtensionService_GetStrainFacets_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stockExtensionServiceClient) CountStrains(ctx context.Context, in *StockCountParameters, opts ...grpc.CallOption) (*StockCount, error) {
+	out := new(StockCount)
+	err := c.cc.Invoke(ctx, StockExtensionService_CountStrains_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stockExtensionServiceClient) CountPlasmids(ctx context.Context, in *StockCountParameters, opts ...grpc.CallOption) (*StockCount, error) {
+	out := new(StockCount)
+	err := c.cc.Invoke(ctx, StockExtensionService_CountPlasmids_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stockExtensionServiceClient) SyncStocks(ctx context.Context, in *StockSyncParameters, opts ...grpc.CallOption) (*StockChangeCollection, error) {
+	out := new(StockChangeCollection)
+	err := c.cc.Invoke(ctx, StockExtensionService_SyncStocks_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stockExtensionServiceClient) GetStrainAncestors(ctx context.Context, in *StrainLineageParameters, opts ...grpc.CallOption) (*StrainRelativeCollection, error) {
+	out := new(StrainRelativeCollection)
+	err := c.cc.Invoke(ctx, StockExtensionService_GetStrainAncestors_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stockExtensionServiceClient) GetStrainDescendants(ctx context.Context, in *StrainLineageParameters, opts ...grpc.CallOption) (*StrainRelativeCollection, error) {
+	out := new(StrainRelativeCollection)
+	err := c.cc.Invoke(ctx, StockExtensionService_GetStrainDescendants_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stockExtensionServiceClient) ExportStrainLineage(ctx context.Context, in *StrainLineageExportParameters, opts ...grpc.CallOption) (*StrainLineageExport, error) {
+	out := new(StrainLineageExport)
+	err := c.cc.Invoke(ctx, StockExtensionService_ExportStrainLineage_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// StockExtensionServiceServer is the server API for StockExtensionService service.
+// All implementations must embed UnimplementedStockExtensionServiceServer
+// for forward compatibility
+type StockExtensionServiceServer interface {
+	// RestoreStock brings back a stock removed by RemoveStock
+	RestoreStock(context.Context, *stock.StockId) (*emptypb.Empty, error)
+	// ListDeletedStocks lists the removed stocks, the most recently removed
+	// one first
+	ListDeletedStocks(context.Context, *DeletedStockParameters) (*DeletedStockCollection, error)
+	// PurgeStock permanently removes a stock that was removed by
+	// RemoveStock, a stock that is not removed is refused
+	PurgeStock(context.Context, *PurgeStockRequest) (*emptypb.Empty, error)
+	// GetStockHistory lists the revisions of a stock, the most recent one
+	// first
+	GetStockHistory(context.Context, *StockHistoryParameters) (*StockRevisionCollection, error)
+	// GetStrainAsOf gets a strain as it existed at a point in time
+	GetStrainAsOf(context.Context, *StockIdAsOf) (*stock.Strain, error)
+	// GetPlasmidAsOf gets a plasmid as it existed at a point in time
+	GetPlasmidAsOf(context.Context, *StockIdAsOf) (*stock.Plasmid, error)
+	// ListStrainsAsOf lists strains as they existed at a point in time
+	ListStrainsAsOf(context.Context, *StockParametersAsOf) (*stock.StrainCollection, error)
+	// RevertStock resets a stock to its state right after a revision
+	RevertStock(context.Context, *StockRevertParameters) (*emptypb.Empty, error)
+	// SearchStocks finds the stocks whose summary, genes, label, names or
+	// plasmid name match the words of the query, the most relevant first
+	SearchStocks(context.Context, *StockSearchParameters) (*StockSearchResult, error)
+	// AutocompleteStocks suggests the stocks whose label, names or plasmid
+	// name start with or resemble the typed text, the closest match first
+	AutocompleteStocks(context.Context, *StockAutocompleteParameters) (*StockSuggestionCollection, error)
+	// ListFilterableFields gives the fields that the list of a stock type
+	// could be filtered by, along with their type and operators
+	ListFilterableFields(context.Context, *FilterableFieldParameters) (*FilterableFieldCollection, error)
+	// GetStrainFacets counts the strains that match a filter by every
+	// distinct value of the facet fields
+	GetStrainFacets(context.Context, *StrainFacetParameters) (*StrainFacetCollection, error)
+	// CountStrains counts the strains that match a filter
+	CountStrains(context.Context, *StockCountParameters) (*StockCount, error)
+	// CountPlasmids counts the plasmids that match a filter
+	CountPlasmids(context.Context, *StockCountParameters) (*StockCount, error)
+	// SyncStocks lists the strains and plasmids that were created, updated
+	// or removed since a watermark, the earliest change first
+	SyncStocks(context.Context, *StockSyncParameters) (*StockChangeCollection, error)
+	// GetStrainAncestors lists the parents of a strain, their parents and
+	// so on, the nearest generation first
+	GetStrainAncestors(context.Context, *StrainLineageParameters) (*StrainRelativeCollection, error)
+	// GetStrainDescendants lists the strains derived from a strain, the
+	// ones derived from them and so on, the nearest generation first
+	GetStrainDescendants(context.Context, *StrainLineageParameters) (*StrainRelativeCollection, error)
+	// ExportStrainLineage exports the ancestor or the descendant tree of a
+	// strain as a Graphviz DOT graph, a Newick tree or a nested JSON tree
+	ExportStrainLineage(context.Context, *StrainLineageExportParameters) (*StrainLineageExport, error)
+	mustEmbedUnimplementedStockExtensionServiceServer()
+}
+
+// UnimplementedStockExtensionServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedStockExtensionServiceServer struct {
+}
+
+func (UnimplementedStockExtensionServiceServer) RestoreStock(context.Context, *stock.StockId) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreStock not implemented")
+}
+func (UnimplementedStockExtensionServiceServer) ListDeletedStocks(context.Context, *DeletedStockParameters) (*DeletedStockCollection, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDeletedStocks not implemented")
+}
+func (UnimplementedStockExtensionServiceServer) PurgeStock(context.Context, *PurgeStockRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PurgeStock not implemented")
+}
+func (UnimplementedStockExtensionServiceServer) GetStockHistory(context.Context, *StockHistoryParameters) (*StockRevisionCollection, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStockHistory not implemented")
+}
+func (UnimplementedStockExtensionServiceServer) GetStrainAsOf(context.Context, *StockIdAsOf) (*stock.Strain, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStrainAsOf not implemented")
+}
+func (UnimplementedStockExtensionServiceServer) GetPlasmidAsOf(context.Context, *StockIdAsOf) (*stock.Plasmid, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPlasmidAsOf not implemented")
+}
+func (UnimplementedStockExtensionServiceServer) ListStrainsAsOf(context.Context, *StockParametersAsOf) (*stock.StrainCollection, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListStrainsAsOf not implemented")
+}
+func (UnimplementedStockExtensionServiceServer) RevertStock(context.Context, *StockRevertParameters) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevertStock not implemented")
+}
+func (UnimplementedStockExtensionServiceServer) SearchStocks(context.Context, *StockSearchParameters) (*StockSearchResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchStocks not implemented")
+}
+func (UnimplementedStockExtensionServiceServer) AutocompleteStocks(context.Context, *StockAutocompleteParameters) (*StockSuggestionCollection, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AutocompleteStocks not implemented")
+}
+func (UnimplementedStockExtensionServiceServer) ListFilterableFields(context.Context, *FilterableFieldParameters) (*FilterableFieldCollection, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListFilterableFields not implemented")
+}
+func (UnimplementedStockExtensionServiceServer) GetStrainFacets(context.Context, *StrainFacetParameters) (*StrainFacetCollection, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStrainFacets not implemented")
+}
+func (UnimplementedStockExtensionServiceServer) CountStrains(context.Context, *StockCountParameters) (*StockCount, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CountStrains not implemented")
+}
+func (UnimplementedStockExtensionServiceServer) CountPlasmids(context.Context, *StockCountParameters) (*StockCount, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CountPlasmids not implemented")
+}
+func (UnimplementedStockExtensionServiceServer) SyncStocks(context.Context, *StockSyncParameters) (*StockChangeCollection, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SyncStocks not implemented")
+}
+func (UnimplementedStockExtensionServiceServer) GetStrainAncestors(context.Context, *StrainLineageParameters) (*StrainRelativeCollection, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStrainAncestors not implemented")
+}
+func (UnimplementedStockExtensionServiceServer) GetStrainDescendants(context.Context, *StrainLineageParameters) (*StrainRelativeCollection, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStrainDescendants not implemented")
+}
+func (UnimplementedStockExtensionServiceServer) ExportStrainLineage(context.Context, *StrainLineageExportParameters) (*StrainLineageExport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportStrainLineage not implemented")
+}
+func (UnimplementedStockExtensionServiceServer) mustEmbedUnimplementedStockExtensionServiceServer() {}
+
+// UnsafeStockExtensionServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to StockExtensionServiceServer will
+// result in compilation errors.
+type UnsafeStockExtensionServiceServer interface {
+	mustEmbedUnimplementedStockExtensionServiceServer()
+}
+
+func RegisterStockExtensionServiceServer(s grpc.ServiceRegistrar, srv StockExtensionServiceServer) {
+	s.RegisterService(&StockExtensionService_ServiceDesc, srv)
+}
+
+func _StockExtensionService_RestoreStock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(stock.StockId)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StockExtensionServiceServer).RestoreStock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StockExtensionService_RestoreStock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StockExtensionServiceServer).RestoreStock(ctx, req.(*stock.StockId))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StockExtensionService_ListDeletedStocks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeletedStockParameters)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StockExtensionServiceServer).ListDeletedStocks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StockExtensionService_ListDeletedStocks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StockExtensionServiceServer).ListDeletedStocks(ctx, req.(*DeletedStockParameters))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StockExtensionService_PurgeStock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PurgeStockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StockExtensionServiceServer).PurgeStock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StockExtensionService_PurgeStock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StockExtensionServiceServer).PurgeStock(ctx, req.(*PurgeStockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StockExtensionService_GetStockHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StockHistoryParameters)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StockExtensionServiceServer).GetStockHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StockExtensionService_GetStockHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StockExtensionServiceServer).GetStockHistory(ctx, req.(*StockHistoryParameters))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StockExtensionService_GetStrainAsOf_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StockIdAsOf)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StockExtensionServiceServer).GetStrainAsOf(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StockExtensionService_GetStrainAsOf_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StockExtensionServiceServer).GetStrainAsOf(ctx, req.(*StockIdAsOf))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StockExtensionService_GetPlasmidAsOf_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StockIdAsOf)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StockExtensionServiceServer).GetPlasmidAsOf(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StockExtensionService_GetPlasmidAsOf_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StockExtensionServiceServer).GetPlasmidAsOf(ctx, req.(*StockIdAsOf))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StockExtensionService_ListStrainsAsOf_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StockParametersAsOf)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StockExtensionServiceServer).ListStrainsAsOf(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StockExtensionService_ListStrainsAsOf_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StockExtensionServiceServer).ListStrainsAsOf(ctx, req.(*StockParametersAsOf))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StockExtensionService_RevertStock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StockRevertParameters)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StockExtensionServiceServer).RevertStock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StockExtensionService_RevertStock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StockExtensionServiceServer).RevertStock(ctx, req.(*StockRevertParameters))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StockExtensionService_SearchStocks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StockSearchParameters)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StockExtensionServiceServer).SearchStocks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StockExtensionService_SearchStocks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StockExtensionServiceServer).SearchStocks(ctx, req.(*StockSearchParameters))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StockExtensionService_AutocompleteStocks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StockAutocompleteParameters)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StockExtensionServiceServer).AutocompleteStocks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StockExtensionService_AutocompleteStocks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StockExtensionServiceServer).AutocompleteStocks(ctx, req.(*StockAutocompleteParameters))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StockExtensionService_ListFilterableFields_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FilterableFieldParameters)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StockExtensionServiceServer).ListFilterableFields(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StockExtensionService_ListFilterableFields_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StockExtensionServiceServer).ListFilterableFields(ctx, req.(*FilterableFieldParameters))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StockExtensionService_GetStrainFacets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StrainFacetParameters)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StockExtensionServiceServer).GetStrainFacets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StockExtensionService_GetStrainFacets_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StockExtensionServiceServer).GetStrainFacets(ctx, req.(*StrainFacetParameters))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StockExtensionService_CountStrains_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StockCountParameters)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StockExtensionServiceServer).CountStrains(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StockExtensionService_CountStrains_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StockExtensionServiceServer).CountStrains(ctx, req.(*StockCountParameters))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StockExtensionService_CountPlasmids_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StockCountParameters)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StockExtensionServiceServer).CountPlasmids(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StockExtensionService_CountPlasmids_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StockExtensionServiceServer).CountPlasmids(ctx, req.(*StockCountParameters))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StockExtensionService_SyncStocks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StockSyncParameters)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StockExtensionServiceServer).SyncStocks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StockExtensionService_SyncStocks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StockExtensionServiceServer).SyncStocks(ctx, req.(*StockSyncParameters))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StockExtensionService_GetStrainAncestors_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StrainLineageParameters)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StockExtensionServiceServer).GetStrainAncestors(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StockExtensionService_GetStrainAncestors_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StockExtensionServiceServer).GetStrainAncestors(ctx, req.(*StrainLineageParameters))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StockExtensionService_GetStrainDescendants_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StrainLineageParameters)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StockExtensionServiceServer).GetStrainDescendants(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StockExtensionService_GetStrainDescendants_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StockExtensionServiceServer).GetStrainDescendants(ctx, req.(*StrainLineageParameters))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StockExtensionService_ExportStrainLineage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StrainLineageExportParameters)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StockExtensionServiceServer).ExportStrainLineage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StockExtensionService_ExportStrainLineage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StockExtensionServiceServer).ExportStrainLineage(ctx, req.(*StrainLineageExportParameters))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// StockExtensionService_ServiceDesc is the grpc.ServiceDesc for StockExtensionService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var StockExtensionService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "dictybase.stockext.StockExtensionService",
+	HandlerType: (*StockExtensionServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "RestoreStock",
+			Handler:    _StockExtensionService_RestoreStock_Handler,
+		},
+		{
+			MethodName: "ListDeletedStocks",
+			Handler:    _StockExtensionService_ListDeletedStocks_Handler,
+		},
+		{
+			MethodName: "PurgeStock",
+			Handler:    _StockExtensionService_PurgeStock_Handler,
+		},
+		{
+			MethodName: "GetStockHistory",
+			Handler:    _StockExtensionService_GetStockHistory_Handler,
+		},
+		{
+			MethodName: "GetStrainAsOf",
+			Handler:    _StockExtensionService_GetStrainAsOf_Handler,
+		},
+		{
+			MethodName: "GetPlasmidAsOf",
+			Handler:    _StockExtensionService_GetPlasmidAsOf_Handler,
+		},
+		{
+			MethodName: "ListStrainsAsOf",
+			Handler:    _StockExtensionService_ListStrainsAsOf_Handler,
+		},
+		{
+			MethodName: "RevertStock",
+			Handler:    _StockExtensionService_RevertStock_Handler,
+		},
+		{
+			MethodName: "SearchStocks",
+			Handler:    _StockExtensionService_SearchStocks_Handler,
+		},
+		{
+			MethodName: "AutocompleteStocks",
+			Handler:    _StockExtensionService_AutocompleteStocks_Handler,
+		},
+		{
+			MethodName: "ListFilterableFields",
+			Handler:    _StockExtensionService_ListFilterableFields_Handler,
+		},
+		{
+			MethodName: "GetStrainFacets",
+			Handler:    _StockExtensionService_GetStrainFacets_Handler,
+		},
+		{
+			MethodName: "CountStrains",
+			Handler:    _StockExtensionService_CountStrains_Handler,
+		},
+		{
+			MethodName: "CountPlasmids",
+			Handler:    _StockExtensionService_CountPlasmids_Handler,
+		},
+		{
+			MethodName: "SyncStocks",
+			Handler:    _StockExtensionService_SyncStocks_Handler,
+		},
+		{
+			MethodName: "GetStrainAncestors",
+			Handler:    _StockExtensionService_GetStrainAncestors_Handler,
+		},
+		{
+			MethodName: "GetStrainDescendants",
+			Handler:    _StockExtensionService_GetStrainDescendants_Handler,
+		},
+		{
+			MethodName: "ExportStrainLineage",
+			Handler:    _StockExtensionService_ExportStrainLineage_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "stockext.proto",
+}
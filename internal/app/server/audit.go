@@ -0,0 +1,44 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/dictyBase/modware-stock/internal/repository/arangodb"
+	"github.com/urfave/cli"
+)
+
+// RunParentAudit reports every cycle of the strain parents, it fails when
+// there is any
+func RunParentAudit(c *cli.Context) error {
+	srepo, err := arangodb.NewStockRepo(allParams(c))
+	if err != nil {
+		return cli.NewExitError(
+			fmt.Sprintf(
+				"cannot connect to arangodb stocks repository %s",
+				err.Error(),
+			),
+			2,
+		)
+	}
+	cycles, err := srepo.ListParentCycles(context.Background())
+	if err != nil {
+		return cli.NewExitError(
+			fmt.Sprintf("error in searching for parent cycles %s", err),
+			2,
+		)
+	}
+	for _, cycle := range cycles {
+		fmt.Fprintln(c.App.Writer, strings.Join(cycle, " -> "))
+	}
+	if len(cycles) > 0 {
+		return cli.NewExitError(
+			fmt.Sprintf("found %d cycles of strain parents", len(cycles)),
+			1,
+		)
+	}
+	log.Print("found no cycle of strain parents")
+	return nil
+}
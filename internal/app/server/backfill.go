@@ -0,0 +1,37 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/dictyBase/modware-stock/internal/repository/arangodb"
+	"github.com/urfave/cli"
+)
+
+// RunBackfill records a baseline revision of the stocks without any, so
+// that they could be read as of a time
+func RunBackfill(c *cli.Context) error {
+	srepo, err := arangodb.NewStockRepo(allParams(c))
+	if err != nil {
+		return cli.NewExitError(
+			fmt.Sprintf(
+				"cannot connect to arangodb stocks repository %s",
+				err.Error(),
+			),
+			2,
+		)
+	}
+	ids, err := srepo.BackfillRevisions(
+		context.Background(),
+		c.String("backfilled-by"),
+	)
+	if err != nil {
+		return cli.NewExitError(
+			fmt.Sprintf("error in backfilling revisions %s", err),
+			2,
+		)
+	}
+	log.Printf("backfilled %d baseline revisions", len(ids))
+	return nil
+}
@@ -0,0 +1,67 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/dictyBase/modware-stock/internal/lineage"
+	"github.com/dictyBase/modware-stock/internal/model"
+	"github.com/dictyBase/modware-stock/internal/repository/arangodb"
+	"github.com/urfave/cli"
+)
+
+// RunLineageExport writes the ancestor or the descendant tree of a strain
+// in the given format, to the output file or else to the standard output
+func RunLineageExport(c *cli.Context) error {
+	srepo, err := arangodb.NewStockRepo(allParams(c))
+	if err != nil {
+		return cli.NewExitError(
+			fmt.Sprintf(
+				"cannot connect to arangodb stocks repository %s",
+				err.Error(),
+			),
+			2,
+		)
+	}
+	g, err := srepo.GetStrainLineageGraph(
+		context.Background(),
+		&model.LineageParams{
+			ID:            c.String("id"),
+			Direction:     c.String("direction"),
+			Depth:         c.Int("depth"),
+			Relationships: c.StringSlice("relationship"),
+		},
+	)
+	if err != nil {
+		return cli.NewExitError(
+			fmt.Sprintf("error in getting lineage of strain %s %s", c.String("id"), err),
+			2,
+		)
+	}
+	data, err := lineage.Export(g, c.String("format"))
+	if err != nil {
+		return cli.NewExitError(
+			fmt.Sprintf("error in exporting lineage %s", err),
+			2,
+		)
+	}
+	// the file is written whole, so that an error in closing it is
+	// reported as well
+	if len(c.String("output")) > 0 {
+		if err := os.WriteFile(c.String("output"), data, 0o644); err != nil {
+			return cli.NewExitError(
+				fmt.Sprintf("error in writing lineage file %s", err),
+				2,
+			)
+		}
+		return nil
+	}
+	if _, err := c.App.Writer.Write(data); err != nil {
+		return cli.NewExitError(
+			fmt.Sprintf("error in writing lineage %s", err),
+			2,
+		)
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/dictyBase/modware-stock/internal/repository/arangodb"
+	"github.com/urfave/cli"
+)
+
+// RunPurge permanently removes the stocks that were deleted earlier than
+// the given age
+func RunPurge(c *cli.Context) error {
+	srepo, err := arangodb.NewStockRepo(allParams(c))
+	if err != nil {
+		return cli.NewExitError(
+			fmt.Sprintf(
+				"cannot connect to arangodb stocks repository %s",
+				err.Error(),
+			),
+			2,
+		)
+	}
+	ids, err := srepo.PurgeDeletedStocks(
+		context.Background(),
+		time.Now().Add(-c.Duration("older-than")),
+		c.String("purged-by"),
+	)
+	if err != nil {
+		return cli.NewExitError(
+			fmt.Sprintf("error in purging deleted stocks %s", err),
+			2,
+		)
+	}
+	log.Printf("purged %d deleted stocks", len(ids))
+	return nil
+}
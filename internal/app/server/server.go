@@ -12,6 +12,7 @@ import (
 	manager "github.com/dictyBase/arangomanager"
 	"github.com/dictyBase/go-genproto/dictybaseapis/stock"
 	ontoarango "github.com/dictyBase/go-obograph/storage/arangodb"
+	"github.com/dictyBase/modware-stock/internal/api/stockext"
 	"github.com/dictyBase/modware-stock/internal/app/service"
 	"github.com/dictyBase/modware-stock/internal/message/nats"
 	"github.com/dictyBase/modware-stock/internal/repository/arangodb"
@@ -54,20 +55,19 @@ func RunServer(c *cli.Context) error {
 			grpc_logrus.UnaryServerInterceptor(getLogger(c)),
 		),
 	)
-	stock.RegisterStockServiceServer(
-		grpcS,
-		service.NewStockService(
-			srepo,
-			ms,
-			aphgrpc.TopicsOption(
-				map[string]string{
-					"stockCreate": "StockService.Create",
-					"stockUpdate": "StockService.Update",
-					"stockDelete": "StockService.Delete",
-				}),
-			strainType(c.String("strain-term")),
-		),
+	srv := service.NewStockService(
+		srepo,
+		ms,
+		aphgrpc.TopicsOption(
+			map[string]string{
+				"stockCreate": "StockService.Create",
+				"stockUpdate": "StockService.Update",
+				"stockDelete": "StockService.Delete",
+			}),
+		serviceParams(c),
 	)
+	stock.RegisterStockServiceServer(grpcS, srv)
+	stockext.RegisterStockExtensionServiceServer(grpcS, srv)
 	if c.Bool("reflection") {
 		// register reflection service on gRPC server
 		reflection.Register(grpcS)
@@ -88,9 +88,15 @@ func RunServer(c *cli.Context) error {
 	return nil
 }
 
-func strainType(term string) aphgrpc.Option {
+func serviceParams(c *cli.Context) aphgrpc.Option {
 	return func(so *aphgrpc.ServiceOptions) {
-		so.Params = map[string]string{"strain_term": term}
+		so.Params = map[string]string{
+			"strain_term":             c.String("strain-term"),
+			service.GetTimeoutParam:   c.Duration("get-timeout").String(),
+			service.ListTimeoutParam:  c.Duration("list-timeout").String(),
+			service.WriteTimeoutParam: c.Duration("write-timeout").String(),
+			service.MaxPageSizeParam:  strconv.FormatInt(c.Int64("max-page-size"), 10),
+		}
 	}
 }
 
@@ -139,6 +145,7 @@ func allParams(
 		Stock:              c.String("stock-collection"),
 		StockProp:          c.String("stockprop-collection"),
 		StockKeyGenerator:  c.String("stock-key-generator-collection"),
+		StockRevision:      c.String("stock-revision-collection"),
 		StockType:          c.String("stock-type-edge"),
 		ParentStrain:       c.String("parent-strain-edge"),
 		StockPropTypeGraph: c.String("stockproptype-graph"),
@@ -147,6 +154,8 @@ func allParams(
 		KeyOffset:          c.Int("keyoffset"),
 		StockTerm:          c.String("stock-term-edge"),
 		StockOntoGraph:     c.String("stockonto-graph"),
+		SearchView:         c.String("search-view"),
+		LockTimeout:        c.Duration("lock-timeout"),
 	}
 	ontoP := &ontoarango.CollectionParams{
 		GraphInfo:    c.String("cv-collection"),
@@ -0,0 +1,120 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dictyBase/aphgrpc"
+	"github.com/dictyBase/go-genproto/dictybaseapis/stock"
+	"github.com/dictyBase/modware-stock/internal/api/stockext"
+	"github.com/dictyBase/modware-stock/internal/model"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// GetStrainAsOf handles getting a strain as it existed at a point in time
+func (s *StockService) GetStrainAsOf(
+	ctx context.Context,
+	r *stockext.StockIdAsOf,
+) (*stock.Strain, error) {
+	st := &stock.Strain{}
+	if err := validateAsOf(r.Id, r.AsOf); err != nil {
+		return st, aphgrpc.HandleInvalidParamError(ctx, err)
+	}
+	ctx, cancel := s.withTimeout(ctx, GetTimeoutParam)
+	defer cancel()
+	m, err := s.repo.GetStrainAsOf(ctx, r.Id, r.AsOf.AsTime())
+	if err != nil {
+		return st, handleError(ctx, err, aphgrpc.HandleGetError)
+	}
+	if m.NotFound {
+		return st, aphgrpc.HandleNotFoundError(
+			ctx,
+			fmt.Errorf(
+				"could not find strain with ID %s at %s",
+				r.Id, r.AsOf.AsTime(),
+			),
+		)
+	}
+	st.Data = makeStrainData(m)
+	return st, nil
+}
+
+// GetPlasmidAsOf handles getting a plasmid as it existed at a point in time
+func (s *StockService) GetPlasmidAsOf(
+	ctx context.Context,
+	r *stockext.StockIdAsOf,
+) (*stock.Plasmid, error) {
+	st := &stock.Plasmid{}
+	if err := validateAsOf(r.Id, r.AsOf); err != nil {
+		return st, aphgrpc.HandleInvalidParamError(ctx, err)
+	}
+	ctx, cancel := s.withTimeout(ctx, GetTimeoutParam)
+	defer cancel()
+	m, err := s.repo.GetPlasmidAsOf(ctx, r.Id, r.AsOf.AsTime())
+	if err != nil {
+		return st, handleError(ctx, err, aphgrpc.HandleGetError)
+	}
+	if m.NotFound {
+		return st, aphgrpc.HandleNotFoundError(
+			ctx,
+			fmt.Errorf(
+				"could not find plasmid with ID %s at %s",
+				r.Id, r.AsOf.AsTime(),
+			),
+		)
+	}
+	st.Data = makePlasmidData(m)
+	return st, nil
+}
+
+// ListStrainsAsOf lists strains as they existed at a point in time
+func (s *StockService) ListStrainsAsOf(
+	ctx context.Context,
+	param *stockext.StockParametersAsOf,
+) (*stock.StrainCollection, error) {
+	if param.AsOf == nil {
+		return &stock.StrainCollection{}, aphgrpc.HandleInvalidParamError(
+			ctx, fmt.Errorf("as of time is required"),
+		)
+	}
+	sp := param.Parameters
+	if sp == nil {
+		sp = &stock.StockParameters{}
+	}
+	limit := s.pageLimit(sp.Limit)
+	scn := &stock.StrainCollection{Meta: &stock.Meta{Limit: limit}}
+	asOf := param.AsOf.AsTime()
+	ctx, cancel := s.withTimeout(ctx, ListTimeoutParam)
+	defer cancel()
+	page, err := stockModelList(&modelListParams{
+		ctx:         ctx,
+		stockParams: sp,
+		limit:       limit,
+		fn: func(
+			ctx context.Context,
+			p *model.ListParams,
+		) ([]*model.StockDoc, error) {
+			return s.repo.ListStrainsAsOf(ctx, p, asOf)
+		},
+		countFn: func(ctx context.Context, filter string) (int64, error) {
+			return s.repo.CountStrainsAsOf(ctx, filter, asOf)
+		},
+	})
+	if err != nil {
+		return scn, err
+	}
+	scn.Data = strainModelToCollectionSlice(page.docs)
+	scn.Meta.Total = page.total
+	scn.Meta.NextCursor = page.nextCursor
+	return scn, nil
+}
+
+func validateAsOf(id string, asOf *timestamppb.Timestamp) error {
+	if len(id) == 0 {
+		return fmt.Errorf("stock id is required")
+	}
+	if asOf == nil {
+		return fmt.Errorf("as of time is required")
+	}
+	return asOf.CheckValid()
+}
@@ -0,0 +1,52 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/dictyBase/aphgrpc"
+	"github.com/dictyBase/modware-stock/internal/api/stockext"
+)
+
+// minAutocompleteLength is the least number of characters typed before
+// any stock is suggested
+const minAutocompleteLength = 2
+
+// AutocompleteStocks suggests the stocks whose label, names or plasmid
+// name start with or resemble the typed text. It is meant to be called
+// on every keystroke, so it is bound by the time limit of getting a single
+// stock and gives an empty list when nothing matches or too little is
+// typed.
+func (s *StockService) AutocompleteStocks(
+	ctx context.Context,
+	r *stockext.StockAutocompleteParameters,
+) (*stockext.StockSuggestionCollection, error) {
+	sc := &stockext.StockSuggestionCollection{
+		Data: []*stockext.StockSuggestion{},
+	}
+	text := strings.TrimSpace(r.Text)
+	if len(text) == 0 {
+		return sc, aphgrpc.HandleInvalidParamError(
+			ctx, fmt.Errorf("text to complete is required"),
+		)
+	}
+	if utf8.RuneCountInString(text) < minAutocompleteLength {
+		return sc, nil
+	}
+	ctx, cancel := s.withTimeout(ctx, GetTimeoutParam)
+	defer cancel()
+	sgs, err := s.repo.SuggestStocks(ctx, text, s.pageLimit(r.Limit))
+	if err != nil {
+		return sc, handleError(ctx, err, aphgrpc.HandleGetError)
+	}
+	for _, sg := range sgs {
+		sc.Data = append(sc.Data, &stockext.StockSuggestion{
+			Id:    sg.ID,
+			Label: sg.Label,
+			Type:  sg.Type,
+		})
+	}
+	return sc, nil
+}
@@ -0,0 +1,41 @@
+package service
+
+import (
+	"context"
+
+	"github.com/dictyBase/modware-stock/internal/api/stockext"
+)
+
+// CountStrains counts the strains that match the filter without
+// retrieving any of them
+func (s *StockService) CountStrains(
+	ctx context.Context,
+	r *stockext.StockCountParameters,
+) (*stockext.StockCount, error) {
+	return s.countStocks(ctx, r, s.repo.CountStrains)
+}
+
+// CountPlasmids counts the plasmids that match the filter without
+// retrieving any of them
+func (s *StockService) CountPlasmids(
+	ctx context.Context,
+	r *stockext.StockCountParameters,
+) (*stockext.StockCount, error) {
+	return s.countStocks(ctx, r, s.repo.CountPlasmids)
+}
+
+func (s *StockService) countStocks(
+	ctx context.Context,
+	r *stockext.StockCountParameters,
+	countFn func(context.Context, string) (int64, error),
+) (*stockext.StockCount, error) {
+	sc := &stockext.StockCount{}
+	ctx, cancel := s.withTimeout(ctx, ListTimeoutParam)
+	defer cancel()
+	total, err := countFn(ctx, r.Filter)
+	if err != nil {
+		return sc, handleError(ctx, err, handleListError)
+	}
+	sc.Total = total
+	return sc, nil
+}
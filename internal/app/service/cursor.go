@@ -0,0 +1,173 @@
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/dictyBase/go-genproto/dictybaseapis/stock"
+	"github.com/dictyBase/modware-stock/internal/model"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	// cursorMetadataKey carries the opaque cursor of the page to list,
+	// it takes the place of the numeric cursor of the list parameters
+	cursorMetadataKey = "x-cursor"
+	// nextCursorMetadataKey carries the opaque cursor of the page that
+	// follows the listed one
+	nextCursorMetadataKey = "x-next-cursor"
+	// prevCursorMetadataKey carries the opaque cursor of the page that
+	// precedes the listed one
+	prevCursorMetadataKey = "x-prev-cursor"
+	// sortMetadataKey carries the comma separated fields to sort a list
+	// by, a field prefixed with - is sorted in descending order
+	sortMetadataKey = "x-sort"
+	// createdCursorVersion only has the time of creation, its list is in
+	// the default sort order
+	createdCursorVersion = 1
+	cursorVersion        = 2
+	defaultPageSize      = 10
+)
+
+// cursorToken is the content of an opaque cursor, the version allows its
+// layout to change without misreading cursors given out earlier
+type cursorToken struct {
+	Version int `json:"v"`
+	// CreatedAt is the time of creation of a version 1 cursor
+	CreatedAt int64 `json:"c,omitempty"`
+	// Sort is the sort order of the list the cursor belongs to
+	Sort     string        `json:"s,omitempty"`
+	Values   []interface{} `json:"vs,omitempty"`
+	Key      string        `json:"k"`
+	Backward bool          `json:"b,omitempty"`
+}
+
+// encodeCursor gives the opaque cursor that continues a list from m, it
+// goes towards the beginning of the list if backward is set
+func encodeCursor(m *model.StockDoc, sort string, backward bool) string {
+	return encodeCursorAt(m.SortValues, m.Key, sort, backward)
+}
+
+// encodeCursorAt gives the opaque cursor for the sort values and the key
+// of a position in a list
+func encodeCursorAt(
+	values []interface{},
+	key, sort string,
+	backward bool,
+) string {
+	ct, _ := json.Marshal(&cursorToken{
+		Version:  cursorVersion,
+		Sort:     sort,
+		Values:   values,
+		Key:      key,
+		Backward: backward,
+	})
+	return base64.RawURLEncoding.EncodeToString(ct)
+}
+
+// decodeCursor reads an opaque cursor of a list in the given sort order
+func decodeCursor(cursor, sort string) (*model.StockCursor, error) {
+	ct, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, fmt.Errorf("malformed cursor %s", cursor)
+	}
+	tok := &cursorToken{}
+	if err := json.Unmarshal(ct, tok); err != nil {
+		return nil, fmt.Errorf("malformed cursor %s", cursor)
+	}
+	switch tok.Version {
+	case createdCursorVersion:
+		tok.Values = []interface{}{tok.CreatedAt}
+	case cursorVersion:
+	default:
+		return nil, fmt.Errorf(
+			"unsupported version %d of cursor %s", tok.Version, cursor,
+		)
+	}
+	if len(tok.Key) == 0 {
+		return nil, fmt.Errorf("cursor %s has no key", cursor)
+	}
+	if tok.Sort != sort {
+		return nil, fmt.Errorf(
+			"cursor %s does not belong to the sort order %q", cursor, sort,
+		)
+	}
+	return &model.StockCursor{
+		Values:   tok.Values,
+		Key:      tok.Key,
+		Backward: tok.Backward,
+	}, nil
+}
+
+// listCursor gives the position from where a list continues. The opaque
+// cursor from the request metadata is preferred over the numeric one,
+// which only has the time of creation and so could repeat the stocks
+// created at that time.
+func listCursor(
+	ctx context.Context,
+	p *stock.StockParameters,
+	sort string,
+) (*model.StockCursor, error) {
+	if cursor := metadataValue(ctx, cursorMetadataKey); len(cursor) > 0 {
+		return decodeCursor(cursor, sort)
+	}
+	if p.Cursor == 0 {
+		return nil, nil
+	}
+	if len(sort) > 0 {
+		return nil, fmt.Errorf("numeric cursor could not be used with sort %q", sort)
+	}
+	return &model.StockCursor{Values: []interface{}{p.Cursor}}, nil
+}
+
+// listSort reads the sort order of a list from the request metadata and
+// gives it along with its normalised form, which is empty for the default
+// order
+func listSort(ctx context.Context) ([]model.SortKey, string, error) {
+	spec := metadataValue(ctx, sortMetadataKey)
+	if len(strings.TrimSpace(spec)) == 0 {
+		return nil, "", nil
+	}
+	var keys []model.SortKey
+	var fields []string
+	seen := make(map[string]bool)
+	for _, f := range strings.Split(spec, ",") {
+		f = strings.TrimSpace(f)
+		key := model.SortKey{Field: strings.TrimLeft(f, "+-")}
+		key.Descending = strings.HasPrefix(f, "-")
+		if len(key.Field) == 0 || len(f)-len(key.Field) > 1 {
+			return nil, "", fmt.Errorf("malformed sort field %q", f)
+		}
+		if seen[key.Field] {
+			return nil, "", fmt.Errorf("sort field %s is repeated", key.Field)
+		}
+		seen[key.Field] = true
+		keys = append(keys, key)
+		if key.Descending {
+			fields = append(fields, "-"+key.Field)
+		} else {
+			fields = append(fields, key.Field)
+		}
+	}
+	return keys, strings.Join(fields, ","), nil
+}
+
+func setCursorHeader(ctx context.Context, key, cursor string) {
+	_ = grpc.SetHeader(ctx, metadata.Pairs(key, cursor))
+}
+
+// pageLimit gives the number of stocks to list in a page, it is capped
+// by the configured maximum page size
+func (s *StockService) pageLimit(limit int64) int64 {
+	if limit <= 0 {
+		limit = defaultPageSize
+	}
+	if s.maxPageSize > 0 && limit > s.maxPageSize {
+		return s.maxPageSize
+	}
+	return limit
+}
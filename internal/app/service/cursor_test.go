@@ -0,0 +1,238 @@
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/dictyBase/modware-stock/internal/model"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// headerStream keeps the headers set by a handler
+type headerStream struct {
+	header metadata.MD
+}
+
+func (hs *headerStream) Method() string { return "" }
+
+func (hs *headerStream) SetHeader(md metadata.MD) error {
+	hs.header = metadata.Join(hs.header, md)
+	return nil
+}
+
+func (hs *headerStream) SendHeader(md metadata.MD) error { return nil }
+
+func (hs *headerStream) SetTrailer(md metadata.MD) error { return nil }
+
+func rawCursor(s string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(s))
+}
+
+func TestDecodeCursor(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		name, cursor, sort string
+		cursorT            *model.StockCursor
+		err                string
+	}{
+		{
+			name:   "created cursor",
+			cursor: rawCursor(`{"v":1,"c":1339665153000,"k":"DBS0236123"}`),
+			cursorT: &model.StockCursor{
+				Values: []interface{}{int64(1339665153000)},
+				Key:    "DBS0236123",
+			},
+		},
+		{
+			name:   "sorted cursor",
+			cursor: encodeCursorAt([]interface{}{"AX4"}, "DBS0236123", "-label", true),
+			sort:   "-label",
+			cursorT: &model.StockCursor{
+				Values:   []interface{}{"AX4"},
+				Key:      "DBS0236123",
+				Backward: true,
+			},
+		},
+		{
+			name:   "sort mismatch",
+			cursor: encodeCursorAt([]interface{}{"AX4"}, "DBS0236123", "-label", false),
+			sort:   "label",
+			err:    `does not belong to the sort order "label"`,
+		},
+		{
+			name:   "created cursor with sort",
+			cursor: rawCursor(`{"v":1,"c":1339665153000,"k":"DBS0236123"}`),
+			sort:   "label",
+			err:    "does not belong to the sort order",
+		},
+		{
+			name:   "unsupported version",
+			cursor: rawCursor(`{"v":3,"k":"DBS0236123"}`),
+			err:    "unsupported version 3",
+		},
+		{
+			name:   "cursor without key",
+			cursor: rawCursor(`{"v":2,"vs":[1]}`),
+			err:    "has no key",
+		},
+		{name: "malformed encoding", cursor: "%%%", err: "malformed cursor"},
+		{name: "malformed token", cursor: rawCursor("[]"), err: "malformed cursor"},
+	} {
+		c, err := decodeCursor(tc.cursor, tc.sort)
+		if len(tc.err) > 0 {
+			require.Errorf(t, err, "expect error from %s", tc.name)
+			require.Containsf(t, err.Error(), tc.err, "should explain the %s", tc.name)
+			continue
+		}
+		require.NoErrorf(t, err, "expect no error from %s", tc.name)
+		require.Equalf(t, tc.cursorT, c, "should read the %s", tc.name)
+	}
+}
+
+func TestListSort(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		spec, sort string
+		keys       []model.SortKey
+		err        bool
+	}{
+		{spec: ""},
+		{spec: "  "},
+		{
+			spec: "label, -created_at",
+			sort: "label,-created_at",
+			keys: []model.SortKey{
+				{Field: "label"},
+				{Field: "created_at", Descending: true},
+			},
+		},
+		{spec: "+label", sort: "label", keys: []model.SortKey{{Field: "label"}}},
+		{spec: "label,-label", err: true},
+		{spec: "--label", err: true},
+		{spec: "label,", err: true},
+		{spec: "-", err: true},
+	} {
+		ctx := metadata.NewIncomingContext(
+			context.Background(), metadata.Pairs(sortMetadataKey, tc.spec),
+		)
+		keys, sort, err := listSort(ctx)
+		if tc.err {
+			require.Errorf(t, err, "expect error from sort %q", tc.spec)
+			continue
+		}
+		require.NoErrorf(t, err, "expect no error from sort %q", tc.spec)
+		require.Equalf(t, tc.keys, keys, "should read the keys of sort %q", tc.spec)
+		require.Equalf(t, tc.sort, sort, "should normalise sort %q", tc.spec)
+	}
+}
+
+func TestPageLimit(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		max, limit, page int64
+	}{
+		{max: 50, limit: 0, page: defaultPageSize},
+		{max: 50, limit: -5, page: defaultPageSize},
+		{max: 50, limit: 20, page: 20},
+		{max: 50, limit: 100, page: 50},
+		{max: 0, limit: 100, page: 100},
+	} {
+		s := &StockService{maxPageSize: tc.max}
+		require.Equalf(
+			t, tc.page, s.pageLimit(tc.limit),
+			"should give %d for limit %d with maximum %d", tc.page, tc.limit, tc.max,
+		)
+	}
+}
+
+func testPageDocs(n int) []*model.StockDoc {
+	tm := time.Date(2012, 6, 14, 9, 12, 33, 0, time.UTC)
+	docs := make([]*model.StockDoc, 0, n)
+	for i := 0; i < n; i++ {
+		m := &model.StockDoc{CreatedAt: tm.Add(-time.Duration(i) * time.Hour)}
+		m.Key = string(rune('a' + i))
+		m.SortValues = []interface{}{m.CreatedAt.UnixMilli()}
+		docs = append(docs, m)
+	}
+	return docs
+}
+
+func TestSetPageCursors(t *testing.T) {
+	t.Parallel()
+	docs := testPageDocs(4)
+	for _, tc := range []struct {
+		name       string
+		docs       []*model.StockDoc
+		cursor     *model.StockCursor
+		sort       string
+		keys       []string
+		nextCursor int64
+		next, prev string
+	}{
+		{
+			name:       "first page",
+			docs:       docs,
+			keys:       []string{"a", "b", "c"},
+			nextCursor: docs[3].CreatedAt.UnixMilli(),
+			next:       "c",
+		},
+		{
+			name:   "short final page",
+			docs:   docs[:2],
+			cursor: &model.StockCursor{Key: "z"},
+			keys:   []string{"a", "b"},
+			prev:   "a",
+		},
+		{
+			name:   "backward page",
+			docs:   docs,
+			cursor: &model.StockCursor{Key: "z", Backward: true},
+			keys:   []string{"c", "b", "a"},
+			next:   "a",
+			prev:   "c",
+		},
+		{
+			name: "sorted page",
+			docs: docs,
+			sort: "label",
+			keys: []string{"a", "b", "c"},
+			next: "c",
+		},
+	} {
+		hs := &headerStream{}
+		ctx := grpc.NewContextWithServerTransportStream(context.Background(), hs)
+		page := &stockPage{docs: append([]*model.StockDoc{}, tc.docs...)}
+		setPageCursors(ctx, page, tc.cursor, tc.sort, 3)
+		keys := make([]string, 0, len(page.docs))
+		for _, m := range page.docs {
+			keys = append(keys, m.Key)
+		}
+		require.Equalf(t, tc.keys, keys, "should list the stocks of the %s", tc.name)
+		require.Equalf(
+			t, tc.nextCursor, page.nextCursor,
+			"should match numeric cursor of the %s", tc.name,
+		)
+		for _, hc := range []struct {
+			key, stock string
+			backward   bool
+		}{
+			{key: nextCursorMetadataKey, stock: tc.next},
+			{key: prevCursorMetadataKey, stock: tc.prev, backward: true},
+		} {
+			values := hs.header.Get(hc.key)
+			if len(hc.stock) == 0 {
+				require.Emptyf(t, values, "should not set %s for the %s", hc.key, tc.name)
+				continue
+			}
+			require.Lenf(t, values, 1, "should set %s for the %s", hc.key, tc.name)
+			c, err := decodeCursor(values[0], tc.sort)
+			require.NoErrorf(t, err, "expect no error from %s of the %s", hc.key, tc.name)
+			require.Equalf(t, hc.stock, c.Key, "should continue %s from its stock", tc.name)
+			require.Equalf(t, hc.backward, c.Backward, "should match direction of %s", hc.key)
+		}
+	}
+}
@@ -0,0 +1,37 @@
+package service
+
+import (
+	"context"
+
+	"github.com/dictyBase/modware-stock/internal/api/stockext"
+)
+
+// GetStrainFacets counts the strains that match the filter by every
+// distinct value of the species, depositor, dicty_strain_property and
+// has_plasmid facets
+func (s *StockService) GetStrainFacets(
+	ctx context.Context,
+	r *stockext.StrainFacetParameters,
+) (*stockext.StrainFacetCollection, error) {
+	fc := &stockext.StrainFacetCollection{Data: []*stockext.Facet{}}
+	ctx, cancel := s.withTimeout(ctx, ListTimeoutParam)
+	defer cancel()
+	facets, err := s.repo.StrainFacets(ctx, r.Filter, r.Fields)
+	if err != nil {
+		return fc, handleError(ctx, err, handleListError)
+	}
+	for _, f := range facets {
+		counts := make([]*stockext.FacetCount, 0, len(f.Counts))
+		for _, c := range f.Counts {
+			counts = append(counts, &stockext.FacetCount{
+				Value: c.Value,
+				Count: c.Count,
+			})
+		}
+		fc.Data = append(fc.Data, &stockext.Facet{
+			Field:  f.Field,
+			Counts: counts,
+		})
+	}
+	return fc, nil
+}
@@ -0,0 +1,38 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dictyBase/aphgrpc"
+	"github.com/dictyBase/modware-stock/internal/api/stockext"
+)
+
+// ListFilterableFields gives the fields that the list of a stock type
+// could be filtered by, along with their type and operators, so that the
+// clients could build their filter forms
+func (s *StockService) ListFilterableFields(
+	ctx context.Context,
+	r *stockext.FilterableFieldParameters,
+) (*stockext.FilterableFieldCollection, error) {
+	fc := &stockext.FilterableFieldCollection{
+		Data: []*stockext.FilterableField{},
+	}
+	if r.Type != "strain" && r.Type != "plasmid" {
+		return fc, aphgrpc.HandleInvalidParamError(
+			ctx, fmt.Errorf("unsupported stock type %q", r.Type),
+		)
+	}
+	ffs, err := s.repo.ListFilterableFields(r.Type)
+	if err != nil {
+		return fc, aphgrpc.HandleInvalidParamError(ctx, err)
+	}
+	for _, ff := range ffs {
+		fc.Data = append(fc.Data, &stockext.FilterableField{
+			Name:      ff.Name,
+			Type:      ff.Type,
+			Operators: ff.Operators,
+		})
+	}
+	return fc, nil
+}
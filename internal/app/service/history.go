@@ -0,0 +1,77 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dictyBase/aphgrpc"
+	"github.com/dictyBase/modware-stock/internal/api/stockext"
+	"github.com/dictyBase/modware-stock/internal/model"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// historySort is the sort order of the cursors of the revisions
+const historySort = "history"
+
+// GetStockHistory lists the revisions of a stock, the most recent one
+// comes first
+func (s *StockService) GetStockHistory(
+	ctx context.Context,
+	r *stockext.StockHistoryParameters,
+) (*stockext.StockRevisionCollection, error) {
+	limit := s.pageLimit(r.Limit)
+	rc := &stockext.StockRevisionCollection{Limit: limit}
+	if len(r.Id) == 0 {
+		return rc, aphgrpc.HandleInvalidParamError(
+			ctx, fmt.Errorf("stock id is required"),
+		)
+	}
+	var cursor *model.StockCursor
+	if len(r.Cursor) > 0 {
+		c, err := decodeCursor(r.Cursor, historySort)
+		if err != nil {
+			return rc, aphgrpc.HandleInvalidParamError(ctx, err)
+		}
+		cursor = c
+	}
+	ctx, cancel := s.withTimeout(ctx, ListTimeoutParam)
+	defer cancel()
+	rms, err := s.repo.ListStockRevisions(ctx, r.Id, cursor, limit)
+	if err != nil {
+		return rc, handleError(ctx, err, aphgrpc.HandleGetError)
+	}
+	if len(rms) == 0 {
+		return rc, aphgrpc.HandleNotFoundError(
+			ctx, fmt.Errorf("could not find any revision of stock %s", r.Id),
+		)
+	}
+	if len(rms) <= int(limit) {
+		rc.Data = revisionSlice(rms)
+		return rc, nil
+	}
+	rc.Data = revisionSlice(rms[:limit])
+	last := rms[limit-1]
+	rc.NextCursor = encodeCursorAt(last.SortValues, last.Key, historySort, false)
+	return rc, nil
+}
+
+func revisionSlice(rms []*model.StockRevision) []*stockext.StockRevision {
+	revs := make([]*stockext.StockRevision, 0, len(rms))
+	for _, rm := range rms {
+		rev := &stockext.StockRevision{
+			Id:        rm.Key,
+			StockId:   rm.StockID,
+			Action:    rm.Action,
+			Actor:     rm.Actor,
+			CreatedAt: timestamppb.New(rm.CreatedAt),
+		}
+		if rm.Before != nil {
+			rev.Before = makeStock(rm.Before)
+		}
+		if rm.After != nil {
+			rev.After = makeStock(rm.After)
+		}
+		revs = append(revs, rev)
+	}
+	return revs
+}
@@ -0,0 +1,183 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/dictyBase/aphgrpc"
+	"github.com/dictyBase/modware-stock/internal/api/stockext"
+	"github.com/dictyBase/modware-stock/internal/lineage"
+	"github.com/dictyBase/modware-stock/internal/model"
+	"github.com/dictyBase/modware-stock/internal/repository"
+)
+
+// GetStrainAncestors lists the parents of a strain, their parents and so
+// on up to the depth, along with the path that leads to each of them
+func (s *StockService) GetStrainAncestors(
+	ctx context.Context,
+	r *stockext.StrainLineageParameters,
+) (*stockext.StrainRelativeCollection, error) {
+	return s.strainLineage(ctx, r, model.LineageAncestors)
+}
+
+// GetStrainDescendants lists the strains derived from a strain, the ones
+// derived from them and so on down to the depth, along with the path that
+// leads to each of them
+func (s *StockService) GetStrainDescendants(
+	ctx context.Context,
+	r *stockext.StrainLineageParameters,
+) (*stockext.StrainRelativeCollection, error) {
+	return s.strainLineage(ctx, r, model.LineageDescendants)
+}
+
+// lineageSort identifies a lineage, so that a cursor could only continue
+// the lineage it was given out for
+func lineageSort(r *stockext.StrainLineageParameters, direction string) string {
+	return fmt.Sprintf(
+		"depth:%s:%s:%d:%s",
+		direction, r.Id, r.Depth, strings.Join(r.Relationships, ","),
+	)
+}
+
+func (s *StockService) strainLineage(
+	ctx context.Context,
+	r *stockext.StrainLineageParameters,
+	direction string,
+) (*stockext.StrainRelativeCollection, error) {
+	limit := s.pageLimit(r.Limit)
+	rc := &stockext.StrainRelativeCollection{
+		Data:  []*stockext.StrainRelative{},
+		Limit: limit,
+	}
+	if len(r.Id) == 0 {
+		return rc, aphgrpc.HandleInvalidParamError(
+			ctx, fmt.Errorf("strain id is required"),
+		)
+	}
+	if r.Depth < 0 {
+		return rc, aphgrpc.HandleInvalidParamError(
+			ctx, fmt.Errorf("depth %d could not be negative", r.Depth),
+		)
+	}
+	if err := validateRelationships(r.Relationships); err != nil {
+		return rc, aphgrpc.HandleInvalidParamError(ctx, err)
+	}
+	p := &model.LineageParams{
+		ID:            r.Id,
+		Direction:     direction,
+		Depth:         int(r.Depth),
+		Relationships: r.Relationships,
+		Limit:         limit,
+		Fields:        responseFields(ctx),
+	}
+	if len(r.Cursor) > 0 {
+		cursor, err := decodeCursor(r.Cursor, lineageSort(r, direction))
+		if err != nil {
+			return rc, aphgrpc.HandleInvalidParamError(ctx, err)
+		}
+		p.Cursor = cursor
+	}
+	ctx, cancel := s.withTimeout(ctx, ListTimeoutParam)
+	defer cancel()
+	relatives, err := s.repo.ListStrainLineage(ctx, p)
+	if err != nil {
+		return rc, handleError(ctx, err, handleLineageError)
+	}
+	if len(relatives) <= int(limit) {
+		rc.Data = relativeSlice(relatives)
+		return rc, nil
+	}
+	rc.Data = relativeSlice(relatives[:limit])
+	rc.NextCursor = encodeCursor(
+		relatives[limit-1].Strain, lineageSort(r, direction), false,
+	)
+	return rc, nil
+}
+
+func relativeSlice(relatives []*model.StrainRelative) []*stockext.StrainRelative {
+	srs := make([]*stockext.StrainRelative, 0, len(relatives))
+	for _, r := range relatives {
+		srs = append(srs, &stockext.StrainRelative{
+			Strain:        makeStrainData(r.Strain),
+			Depth:         int32(r.Depth),
+			Path:          r.Path,
+			Relationships: r.Relationships,
+		})
+	}
+	return srs
+}
+
+// ExportStrainLineage exports the ancestor or the descendant tree of a
+// strain as a Graphviz DOT graph, a Newick tree or a nested JSON tree, every
+// strain is labelled with its id, label and strain property
+func (s *StockService) ExportStrainLineage(
+	ctx context.Context,
+	r *stockext.StrainLineageExportParameters,
+) (*stockext.StrainLineageExport, error) {
+	ex := &stockext.StrainLineageExport{Format: r.Format}
+	if err := validateLineageExport(r); err != nil {
+		return ex, aphgrpc.HandleInvalidParamError(ctx, err)
+	}
+	ctx, cancel := s.withTimeout(ctx, ListTimeoutParam)
+	defer cancel()
+	g, err := s.repo.GetStrainLineageGraph(ctx, &model.LineageParams{
+		ID:            r.Id,
+		Direction:     r.Direction,
+		Depth:         int(r.Depth),
+		Relationships: r.Relationships,
+	})
+	if err != nil {
+		return ex, handleError(ctx, err, handleLineageError)
+	}
+	data, err := lineage.Export(g, r.Format)
+	if err != nil {
+		return ex, aphgrpc.HandleGenericError(ctx, err)
+	}
+	ex.ContentType = lineage.ContentType(r.Format)
+	ex.Data = data
+	return ex, nil
+}
+
+func validateLineageExport(r *stockext.StrainLineageExportParameters) error {
+	if len(r.Id) == 0 {
+		return fmt.Errorf("strain id is required")
+	}
+	if r.Direction != model.LineageAncestors &&
+		r.Direction != model.LineageDescendants {
+		return fmt.Errorf(
+			"unsupported direction %s, valid directions are %s and %s",
+			r.Direction, model.LineageAncestors, model.LineageDescendants,
+		)
+	}
+	if r.Depth < 0 {
+		return fmt.Errorf("depth %d could not be negative", r.Depth)
+	}
+	if !lineage.IsFormat(r.Format) {
+		return fmt.Errorf(
+			"unsupported format %s, valid formats are %s",
+			r.Format, strings.Join(lineage.Formats, ", "),
+		)
+	}
+	return validateRelationships(r.Relationships)
+}
+
+func validateRelationships(relationships []string) error {
+	for _, rel := range relationships {
+		if !model.IsParentRelationship(rel) {
+			return fmt.Errorf(
+				"unsupported relationship %s, valid relationships are %s",
+				rel, strings.Join(model.ParentRelationships, ", "),
+			)
+		}
+	}
+	return nil
+}
+
+func handleLineageError(ctx context.Context, err error) error {
+	if errors.Is(err, repository.ErrStockNotFound) {
+		return aphgrpc.HandleNotFoundError(ctx, err)
+	}
+	return handleListError(ctx, err)
+}
@@ -19,9 +19,11 @@ func (s *StockService) CreatePlasmid(
 		return st, aphgrpc.HandleInvalidParamError(ctx, err)
 	}
 
-	m, err := s.repo.AddPlasmid(r)
+	ctx, cancel := s.withTimeout(ctx, WriteTimeoutParam)
+	defer cancel()
+	m, err := s.repo.AddPlasmid(ctx, r)
 	if err != nil {
-		return st, aphgrpc.HandleInsertError(ctx, err)
+		return st, handleError(ctx, err, aphgrpc.HandleInsertError)
 	}
 	st.Data = makePlasmidData(m)
 	err = s.publisher.PublishPlasmid(s.Topics["stockCreate"], st)
@@ -41,9 +43,11 @@ func (s *StockService) GetPlasmid(
 		return st, aphgrpc.HandleInvalidParamError(ctx, err)
 	}
 
-	m, err := s.repo.GetPlasmid(r.Id)
+	ctx, cancel := s.withTimeout(ctx, GetTimeoutParam)
+	defer cancel()
+	m, err := s.repo.GetPlasmid(ctx, r.Id, responseFields(ctx)...)
 	if err != nil {
-		return st, aphgrpc.HandleGetError(ctx, err)
+		return st, handleError(ctx, err, handleGetError)
 	}
 	if m.NotFound {
 		return st,
@@ -53,6 +57,7 @@ func (s *StockService) GetPlasmid(
 			)
 	}
 	st.Data = makePlasmidData(m)
+	setRevisionHeader(ctx, m)
 	return st, nil
 }
 
@@ -65,9 +70,11 @@ func (s *StockService) UpdatePlasmid(
 	if err := r.Validate(); err != nil {
 		return st, aphgrpc.HandleInvalidParamError(ctx, err)
 	}
-	m, err := s.repo.EditPlasmid(r)
+	ctx, cancel := s.withTimeout(ctx, WriteTimeoutParam)
+	defer cancel()
+	m, err := s.repo.EditPlasmid(ctx, r, expectedRevision(ctx))
 	if err != nil {
-		return st, aphgrpc.HandleUpdateError(ctx, err)
+		return st, handleError(ctx, err, handleEditError)
 	}
 	if m.NotFound {
 		return st, aphgrpc.HandleNotFoundError(
@@ -91,6 +98,7 @@ func (s *StockService) UpdatePlasmid(
 			Name:            m.PlasmidProperties.Name,
 		},
 	}
+	setRevisionHeader(ctx, m)
 	err = s.publisher.PublishPlasmid(s.Topics["stockUpdate"], st)
 	if err != nil {
 		return st, aphgrpc.HandleMessagingPubError(ctx, err)
@@ -103,28 +111,23 @@ func (s *StockService) ListPlasmids(
 	ctx context.Context,
 	r *stock.StockParameters,
 ) (*stock.PlasmidCollection, error) {
-	limit := limitVal(r.Limit)
+	limit := s.pageLimit(r.Limit)
 	pc := &stock.PlasmidCollection{Meta: &stock.Meta{Limit: limit}}
-	mc, err := stockModelList(&modelListParams{
+	ctx, cancel := s.withTimeout(ctx, ListTimeoutParam)
+	defer cancel()
+	page, err := stockModelList(&modelListParams{
 		ctx:         ctx,
 		stockParams: r,
 		limit:       limit,
 		fn:          s.repo.ListPlasmids,
+		countFn:     s.repo.CountPlasmids,
 	})
 	if err != nil {
 		return pc, err
 	}
-	pdata := plasmidModelToCollectionSlice(mc)
-	if len(pdata) < int(limit)-2 { // fewer results than limit
-		pc.Data = pdata
-		pc.Meta.Total = int64(len(pdata))
-		return pc, nil
-	}
-	pc.Data = pdata[:len(pdata)-1]
-	pc.Meta.NextCursor = genNextCursorVal(
-		pdata[len(pdata)-1].Attributes.CreatedAt,
-	)
-	pc.Meta.Total = int64(len(pdata))
+	pc.Data = plasmidModelToCollectionSlice(page.docs)
+	pc.Meta.Total = page.total
+	pc.Meta.NextCursor = page.nextCursor
 	return pc, nil
 }
 
@@ -139,9 +142,11 @@ func (s *StockService) LoadPlasmid(
 	}
 	id := r.Data.Id
 
-	m, err := s.repo.LoadPlasmid(id, r)
+	ctx, cancel := s.withTimeout(ctx, WriteTimeoutParam)
+	defer cancel()
+	m, err := s.repo.LoadPlasmid(ctx, id, r)
 	if err != nil {
-		return st, aphgrpc.HandleInsertError(ctx, err)
+		return st, handleError(ctx, err, aphgrpc.HandleInsertError)
 	}
 	st.Data = makePlasmidData(m)
 	err = s.publisher.PublishPlasmid(s.Topics["stockCreate"], st)
@@ -175,8 +180,8 @@ func plasmidModelToCollectionSlice(
 
 func makePlasmidAttr(m *model.StockDoc) *stock.PlasmidAttributes {
 	return &stock.PlasmidAttributes{
-		CreatedAt:       aphgrpc.TimestampProto(m.CreatedAt),
-		UpdatedAt:       aphgrpc.TimestampProto(m.UpdatedAt),
+		CreatedAt:       timestampProto(m.CreatedAt),
+		UpdatedAt:       timestampProto(m.UpdatedAt),
 		CreatedBy:       m.CreatedBy,
 		UpdatedBy:       m.UpdatedBy,
 		Summary:         m.Summary,
@@ -0,0 +1,45 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dictyBase/aphgrpc"
+	"github.com/dictyBase/go-genproto/dictybaseapis/stock"
+	"github.com/dictyBase/modware-stock/internal/api/stockext"
+	empty "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// RevertStock resets a stock to its state right after the given revision
+// and publishes the result as a stock update
+func (s *StockService) RevertStock(
+	ctx context.Context,
+	r *stockext.StockRevertParameters,
+) (*empty.Empty, error) {
+	e := &empty.Empty{}
+	if len(r.Id) == 0 || len(r.Revision) == 0 {
+		return e, aphgrpc.HandleInvalidParamError(
+			ctx, fmt.Errorf("stock id and revision are required"),
+		)
+	}
+	ctx, cancel := s.withTimeout(ctx, WriteTimeoutParam)
+	defer cancel()
+	m, err := s.repo.RevertStock(ctx, r.Id, r.Revision, actorFromContext(ctx))
+	if err != nil {
+		return e, handleError(ctx, err, handleEditError)
+	}
+	if m.StrainProperties != nil {
+		st := &stock.Strain{Data: makeStrainData(m)}
+		st.Data.Attributes.DictyStrainProperty = ""
+		err = s.publisher.PublishStrain(s.Topics["stockUpdate"], st)
+	} else {
+		err = s.publisher.PublishPlasmid(
+			s.Topics["stockUpdate"],
+			&stock.Plasmid{Data: makePlasmidData(m)},
+		)
+	}
+	if err != nil {
+		return e, aphgrpc.HandleMessagingPubError(ctx, err)
+	}
+	return e, nil
+}
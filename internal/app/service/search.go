@@ -0,0 +1,92 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/dictyBase/aphgrpc"
+	"github.com/dictyBase/modware-stock/internal/api/stockext"
+	"github.com/dictyBase/modware-stock/internal/model"
+)
+
+// searchSort identifies the ranking of a search, so that a cursor could
+// only continue the search it was given out for
+func searchSort(r *stockext.StockSearchParameters) string {
+	return fmt.Sprintf("score:%s:%s", r.Type, strings.TrimSpace(r.Query))
+}
+
+// SearchStocks finds the stocks whose summary, genes, label, names or
+// plasmid name match the words of the query
+func (s *StockService) SearchStocks(
+	ctx context.Context,
+	r *stockext.StockSearchParameters,
+) (*stockext.StockSearchResult, error) {
+	limit := s.pageLimit(r.Limit)
+	sr := &stockext.StockSearchResult{Limit: limit}
+	if len(strings.TrimSpace(r.Query)) == 0 {
+		return sr, aphgrpc.HandleInvalidParamError(
+			ctx, fmt.Errorf("search query is required"),
+		)
+	}
+	if r.Type != "" && r.Type != "strain" && r.Type != "plasmid" {
+		return sr, aphgrpc.HandleInvalidParamError(
+			ctx, fmt.Errorf("unsupported stock type %s", r.Type),
+		)
+	}
+	p := &model.SearchParams{
+		Query:     r.Query,
+		StockType: r.Type,
+		Limit:     limit,
+	}
+	if len(r.Cursor) > 0 {
+		cursor, err := decodeCursor(r.Cursor, searchSort(r))
+		if err != nil {
+			return sr, aphgrpc.HandleInvalidParamError(ctx, err)
+		}
+		p.Cursor = cursor
+	}
+	ctx, cancel := s.withTimeout(ctx, ListTimeoutParam)
+	defer cancel()
+	hits, err := s.repo.SearchStocks(ctx, p)
+	if err != nil {
+		return sr, handleError(ctx, err, handleListError)
+	}
+	if len(hits) == 0 {
+		return sr, aphgrpc.HandleNotFoundError(
+			ctx, fmt.Errorf("could not find any stocks matching %q", r.Query),
+		)
+	}
+	if len(hits) <= int(limit) {
+		sr.Data = searchHitSlice(hits)
+		return sr, nil
+	}
+	sr.Data = searchHitSlice(hits[:limit])
+	sr.NextCursor = encodeCursor(hits[limit-1].Stock, searchSort(r), false)
+	return sr, nil
+}
+
+func searchHitSlice(hits []*model.StockSearchHit) []*stockext.StockSearchHit {
+	shits := make([]*stockext.StockSearchHit, 0, len(hits))
+	for _, h := range hits {
+		fields := make([]string, 0, len(h.Highlights))
+		for f := range h.Highlights {
+			fields = append(fields, f)
+		}
+		sort.Strings(fields)
+		hls := make([]*stockext.StockSearchHighlight, 0, len(fields))
+		for _, f := range fields {
+			hls = append(hls, &stockext.StockSearchHighlight{
+				Field:    f,
+				Snippets: h.Highlights[f],
+			})
+		}
+		shits = append(shits, &stockext.StockSearchHit{
+			Stock:      makeStock(h.Stock),
+			Score:      h.Score,
+			Highlights: hls,
+		})
+	}
+	return shits
+}
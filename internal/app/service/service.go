@@ -5,37 +5,85 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/dictyBase/aphgrpc"
-	"github.com/dictyBase/arangomanager/query"
 	"github.com/dictyBase/go-genproto/dictybaseapis/api/upload"
 	"github.com/dictyBase/go-genproto/dictybaseapis/stock"
 	"github.com/dictyBase/go-obograph/storage"
+	"github.com/dictyBase/modware-stock/internal/api/stockext"
 	"github.com/dictyBase/modware-stock/internal/message"
 	"github.com/dictyBase/modware-stock/internal/model"
 	"github.com/dictyBase/modware-stock/internal/repository"
-	"github.com/dictyBase/modware-stock/internal/repository/arangodb"
 	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 	empty "google.golang.org/protobuf/types/known/emptypb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
-type listFn func(*stock.StockParameters) ([]*model.StockDoc, error)
+const (
+	actorMetadataKey = "x-user"
+	// revisionMetadataKey carries the combined revision of a stock in the
+	// response header of reads and updates
+	revisionMetadataKey = "x-revision"
+	// ifMatchMetadataKey carries the revision an update expects the stock
+	// to be at
+	ifMatchMetadataKey = "if-match"
+	// fieldsMetadataKey carries the comma separated attributes that the
+	// stocks of a get or a list response are limited to
+	fieldsMetadataKey = "x-fields"
+)
+
+// Service parameters for the time limit of each kind of repository
+// operation, the values are in the format of time.ParseDuration
+const (
+	GetTimeoutParam   = "get_timeout"
+	ListTimeoutParam  = "list_timeout"
+	WriteTimeoutParam = "write_timeout"
+	// MaxPageSizeParam is the service parameter for the largest number of
+	// stocks listed in a single page
+	MaxPageSizeParam = "max_page_size"
+)
+
+type listFn func(
+	context.Context,
+	*model.ListParams,
+) ([]*model.StockDoc, error)
 
 type modelListParams struct {
 	ctx         context.Context
 	stockParams *stock.StockParameters
 	limit       int64
 	fn          listFn
+	// countFn counts all the stocks matching the filter, the total is the
+	// size of the page in its absence
+	countFn func(context.Context, string) (int64, error)
+}
+
+// stockPage is a page of a stock list
+type stockPage struct {
+	docs  []*model.StockDoc
+	total int64
+	// nextCursor is the numeric cursor of the next page, it is zero for
+	// the last page
+	nextCursor int64
 }
 
 // StockService is the container for managing stock service
 // definition
 type StockService struct {
 	*aphgrpc.Service
-	repo      repository.StockRepository
-	publisher message.Publisher
+	repo        repository.StockRepository
+	publisher   message.Publisher
+	timeouts    map[string]time.Duration
+	maxPageSize int64
 	stock.UnimplementedStockServiceServer
+	stockext.UnimplementedStockExtensionServiceServer
 }
 
 func defaultOptions() *aphgrpc.ServiceOptions {
@@ -55,13 +103,65 @@ func NewStockService(
 	srv := &aphgrpc.Service{}
 	aphgrpc.AssignFieldsToStructs(s, srv)
 	return &StockService{
-		Service:   srv,
-		repo:      repo,
-		publisher: pub,
+		Service:     srv,
+		repo:        repo,
+		publisher:   pub,
+		timeouts:    parseTimeouts(s.Params),
+		maxPageSize: parseMaxPageSize(s.Params),
+	}
+}
+
+func parseTimeouts(params map[string]string) map[string]time.Duration {
+	timeouts := make(map[string]time.Duration)
+	for _, k := range []string{
+		GetTimeoutParam, ListTimeoutParam, WriteTimeoutParam,
+	} {
+		if d, err := time.ParseDuration(params[k]); err == nil {
+			timeouts[k] = d
+		}
+	}
+	return timeouts
+}
+
+func parseMaxPageSize(params map[string]string) int64 {
+	size, err := strconv.ParseInt(params[MaxPageSizeParam], 10, 64)
+	if err != nil {
+		return 0
+	}
+	return size
+}
+
+// withTimeout limits ctx by the time configured for the given kind of
+// operation, it is left as is in absence of any such limit
+func (s *StockService) withTimeout(
+	ctx context.Context,
+	param string,
+) (context.Context, context.CancelFunc) {
+	if d := s.timeouts[param]; d > 0 {
+		return context.WithTimeout(ctx, d)
+	}
+	return context.WithCancel(ctx)
+}
+
+// handleError reports an operation that ran out of time or was cancelled
+// by the client with the matching grpc status, any other error is
+// reported by fn
+func handleError(
+	ctx context.Context,
+	err error,
+	fn func(context.Context, error) error,
+) error {
+	switch ctx.Err() {
+	case context.DeadlineExceeded:
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	case context.Canceled:
+		return status.Error(codes.Canceled, err.Error())
 	}
+	return fn(ctx, err)
 }
 
-// RemoveStock removes an existing stock
+// RemoveStock marks an existing stock as deleted, it could be brought back
+// with RestoreStock
 func (s *StockService) RemoveStock(
 	ctx context.Context,
 	r *stock.StockId,
@@ -70,8 +170,12 @@ func (s *StockService) RemoveStock(
 	if err := r.Validate(); err != nil {
 		return e, aphgrpc.HandleInvalidParamError(ctx, err)
 	}
-	if err := s.repo.RemoveStock(r.Id); err != nil {
-		return e, aphgrpc.HandleDeleteError(ctx, err)
+	ctx, cancel := s.withTimeout(ctx, WriteTimeoutParam)
+	defer cancel()
+	if err := s.repo.RemoveStock(
+		ctx, r.Id, actorFromContext(ctx),
+	); err != nil {
+		return e, handleError(ctx, err, handleRemoveError)
 	}
 	return e, nil
 }
@@ -84,12 +188,13 @@ func (s *StockService) OboJSONFileUpload(
 	defer in.Close()
 	oh := &oboStreamHandler{writer: out, stream: stream}
 	grp.Go(oh.Write)
-	m, err := s.repo.LoadOboJSON(in)
+	ctx := stream.Context()
+	m, err := s.repo.LoadOboJSON(ctx, in)
 	if err != nil {
-		return aphgrpc.HandleGenericError(context.Background(), err)
+		return handleError(ctx, err, aphgrpc.HandleGenericError)
 	}
 	if err := grp.Wait(); err != nil {
-		return aphgrpc.HandleGenericError(context.Background(), err)
+		return aphgrpc.HandleGenericError(ctx, err)
 	}
 	return stream.SendAndClose(&upload.FileUploadResponse{
 		Status: uploadResponse(m),
@@ -106,60 +211,160 @@ func uploadResponse(
 	return upload.FileUploadResponse_UPDATED
 }
 
-func genNextCursorVal(pts *timestamppb.Timestamp) int64 {
-	tstmp := aphgrpc.ProtoTimeStamp(pts)
-	return tstmp.UnixMilli()
+// actorFromContext returns the user making the request from the incoming
+// grpc metadata, it is used by requests without any dedicated field for it
+func actorFromContext(ctx context.Context) string {
+	return metadataValue(ctx, actorMetadataKey)
 }
 
-func stockAQLStatement(fstr string) (string, error) {
-	filterSlice, err := query.ParseFilterString(fstr)
-	if err != nil {
-		return "", fmt.Errorf("error in parsing filter string %s", err)
+// expectedRevision returns the revision of the stock an update is based on,
+// it is empty for unconditional updates
+func expectedRevision(ctx context.Context) string {
+	return metadataValue(ctx, ifMatchMetadataKey)
+}
+
+// setRevisionHeader sends the current revision of the stock to the client
+func setRevisionHeader(ctx context.Context, m *model.StockDoc) {
+	if rev := m.Revision(); len(rev) > 0 {
+		_ = grpc.SetHeader(ctx, metadata.Pairs(revisionMetadataKey, rev))
 	}
-	stmt, err := query.GenQualifiedAQLFilterStatement(
-		arangodb.FMap,
-		filterSlice,
-	)
-	if err != nil {
-		return stmt, fmt.Errorf("error in generating AQL statement %s", err)
+}
+
+// responseFields gives the attributes the response is limited to, all of
+// them are given without any
+func responseFields(ctx context.Context) []string {
+	var fields []string
+	for _, f := range strings.Split(metadataValue(ctx, fieldsMetadataKey), ",") {
+		if f = strings.TrimSpace(f); len(f) > 0 {
+			fields = append(fields, f)
+		}
 	}
-	// if the parsed statement is empty FILTER, just return empty string
-	if stmt == "FILTER " {
-		stmt = ""
+	return fields
+}
+
+// timestampProto converts a time that is not zero, the time of a stock
+// is zero when it is left out of the response
+func timestampProto(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return aphgrpc.TimestampProto(t)
+}
+
+func metadataValue(ctx context.Context, key string) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	if v := md.Get(key); len(v) > 0 {
+		return v[0]
 	}
-	return stmt, nil
+	return ""
 }
 
-func stockModelList(args *modelListParams) ([]*model.StockDoc, error) {
-	astmt, err := stockAQLStatement(args.stockParams.Filter)
+func handleListError(ctx context.Context, err error) error {
+	if errors.Is(err, repository.ErrInvalidFilter) ||
+		errors.Is(err, repository.ErrInvalidSort) ||
+		errors.Is(err, repository.ErrInvalidFacet) ||
+		errors.Is(err, repository.ErrInvalidField) {
+		return aphgrpc.HandleInvalidParamError(ctx, err)
+	}
+	return aphgrpc.HandleGetError(ctx, err)
+}
+
+func handleGetError(ctx context.Context, err error) error {
+	if errors.Is(err, repository.ErrInvalidField) {
+		return aphgrpc.HandleInvalidParamError(ctx, err)
+	}
+	return aphgrpc.HandleGetError(ctx, err)
+}
+
+// stockModelList gives a page of stocks. The opaque cursors of the
+// adjacent pages are sent to the client as response headers.
+func stockModelList(args *modelListParams) (*stockPage, error) {
+	page := &stockPage{}
+	sortKeys, sort, err := listSort(args.ctx)
 	if err != nil {
-		return []*model.StockDoc{}, aphgrpc.HandleInvalidParamError(
-			args.ctx,
-			err,
-		)
+		return page, aphgrpc.HandleInvalidParamError(args.ctx, err)
 	}
-	mc, err := args.fn(&stock.StockParameters{
-		Cursor: args.stockParams.Cursor,
-		Limit:  args.limit,
-		Filter: astmt,
-	})
+	cursor, err := listCursor(args.ctx, args.stockParams, sort)
 	if err != nil {
-		return mc, aphgrpc.HandleGetError(args.ctx, err)
+		return page, aphgrpc.HandleInvalidParamError(args.ctx, err)
 	}
-	if len(mc) == 0 {
-		return mc,
-			aphgrpc.HandleNotFoundError(
-				args.ctx, errors.New("could not find any strains"),
-			)
+	grp, ctx := errgroup.WithContext(args.ctx)
+	grp.Go(func() error {
+		mc, err := args.fn(ctx, &model.ListParams{
+			Cursor: cursor,
+			Limit:  args.limit,
+			Filter: args.stockParams.Filter,
+			Sort:   sortKeys,
+			Fields: responseFields(args.ctx),
+		})
+		page.docs = mc
+		return err
+	})
+	if args.countFn != nil {
+		grp.Go(func() error {
+			total, err := args.countFn(ctx, args.stockParams.Filter)
+			page.total = total
+			return err
+		})
+	}
+	if err := grp.Wait(); err != nil {
+		return page, handleError(args.ctx, err, handleListError)
 	}
-	return mc, nil
+	if len(page.docs) == 0 {
+		return page, aphgrpc.HandleNotFoundError(
+			args.ctx, errors.New("could not find any stocks"),
+		)
+	}
+	setPageCursors(args.ctx, page, cursor, sort, args.limit)
+	if args.countFn == nil {
+		page.total = int64(len(page.docs))
+	}
+	return page, nil
 }
 
-func limitVal(limit int64) int64 {
-	if limit > 0 {
-		return limit
+// setPageCursors trims the extra stock fetched beyond the limit, which
+// tells whether the list goes on past the page, and sets the cursors of
+// the adjacent pages. The stocks of a backward page are fetched nearest
+// to the cursor first, they are put back in the order of the list.
+func setPageCursors(
+	ctx context.Context,
+	page *stockPage,
+	cursor *model.StockCursor,
+	sort string,
+	limit int64,
+) {
+	more := int64(len(page.docs)) > limit
+	if more {
+		// the numeric cursor only follows the default sort order
+		if len(sort) == 0 && (cursor == nil || !cursor.Backward) {
+			page.nextCursor = page.docs[limit].CreatedAt.UnixMilli()
+		}
+		page.docs = page.docs[:limit]
+	}
+	backward := cursor != nil && cursor.Backward
+	if backward {
+		for i, j := 0, len(page.docs)-1; i < j; i, j = i+1, j-1 {
+			page.docs[i], page.docs[j] = page.docs[j], page.docs[i]
+		}
+	}
+	first, last := page.docs[0], page.docs[len(page.docs)-1]
+	switch {
+	case backward:
+		setCursorHeader(ctx, nextCursorMetadataKey, encodeCursor(last, sort, false))
+		if more {
+			setCursorHeader(ctx, prevCursorMetadataKey, encodeCursor(first, sort, true))
+		}
+	default:
+		if more {
+			setCursorHeader(ctx, nextCursorMetadataKey, encodeCursor(last, sort, false))
+		}
+		if cursor != nil {
+			setCursorHeader(ctx, prevCursorMetadataKey, encodeCursor(first, sort, true))
+		}
 	}
-	return int64(10)
 }
 
 type oboStreamHandler struct {
@@ -0,0 +1,179 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/dictyBase/aphgrpc"
+	"github.com/dictyBase/go-genproto/dictybaseapis/stock"
+	"github.com/dictyBase/modware-stock/internal/api/stockext"
+	"github.com/dictyBase/modware-stock/internal/model"
+	"github.com/dictyBase/modware-stock/internal/repository"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	empty "google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// deletedSort is the sort order of the cursors of the removed stocks
+const deletedSort = "deleted"
+
+// RestoreStock brings back a stock removed by RemoveStock
+func (s *StockService) RestoreStock(
+	ctx context.Context,
+	r *stock.StockId,
+) (*empty.Empty, error) {
+	e := &empty.Empty{}
+	if err := r.Validate(); err != nil {
+		return e, aphgrpc.HandleInvalidParamError(ctx, err)
+	}
+	ctx, cancel := s.withTimeout(ctx, WriteTimeoutParam)
+	defer cancel()
+	if err := s.repo.RestoreStock(ctx, r.Id, actorFromContext(ctx)); err != nil {
+		return e, handleError(ctx, err, handleRestoreError)
+	}
+	return e, nil
+}
+
+// ListDeletedStocks lists the removed stocks, the most recently removed
+// one comes first
+func (s *StockService) ListDeletedStocks(
+	ctx context.Context,
+	r *stockext.DeletedStockParameters,
+) (*stockext.DeletedStockCollection, error) {
+	limit := s.pageLimit(r.Limit)
+	dc := &stockext.DeletedStockCollection{Limit: limit}
+	var cursor *model.StockCursor
+	if len(r.Cursor) > 0 {
+		c, err := decodeCursor(r.Cursor, deletedSort)
+		if err != nil {
+			return dc, aphgrpc.HandleInvalidParamError(ctx, err)
+		}
+		cursor = c
+	}
+	ctx, cancel := s.withTimeout(ctx, ListTimeoutParam)
+	defer cancel()
+	mc, err := s.repo.ListDeletedStocks(ctx, cursor, limit)
+	if err != nil {
+		return dc, handleError(ctx, err, aphgrpc.HandleGetError)
+	}
+	if len(mc) == 0 {
+		return dc, aphgrpc.HandleNotFoundError(
+			ctx, errors.New("could not find any deleted stocks"),
+		)
+	}
+	if len(mc) <= int(limit) {
+		dc.Data = deletedStockSlice(mc)
+		return dc, nil
+	}
+	dc.Data = deletedStockSlice(mc[:limit])
+	last := mc[limit-1]
+	dc.NextCursor = encodeCursorAt(last.SortValues, last.Key, deletedSort, false)
+	return dc, nil
+}
+
+func deletedStockSlice(mc []*model.StockDoc) []*stockext.DeletedStock {
+	dstocks := make([]*stockext.DeletedStock, 0, len(mc))
+	for _, m := range mc {
+		ds := &stockext.DeletedStock{
+			Stock:     makeStock(m),
+			DeletedBy: m.DeletedBy,
+		}
+		if m.DeletedAt != nil {
+			ds.DeletedAt = timestamppb.New(*m.DeletedAt)
+		}
+		dstocks = append(dstocks, ds)
+	}
+	return dstocks
+}
+
+// makeStock gives either the strain or the plasmid of the stock, it is
+// empty for a stock without the properties of either
+func makeStock(m *model.StockDoc) *stockext.Stock {
+	switch {
+	case m.StrainProperties != nil:
+		return &stockext.Stock{
+			Data: &stockext.Stock_Strain{Strain: makeStrainData(m)},
+		}
+	case m.PlasmidProperties != nil:
+		return &stockext.Stock{
+			Data: &stockext.Stock_Plasmid{Plasmid: makePlasmidData(m)},
+		}
+	}
+	return &stockext.Stock{}
+}
+
+// PurgeStock permanently removes a stock that was removed by RemoveStock,
+// a stock that is not removed is refused. With cascade, strains
+// that refer to it are detached from the stock, otherwise the removal fails
+// if any such strain exists.
+func (s *StockService) PurgeStock(
+	ctx context.Context,
+	r *stockext.PurgeStockRequest,
+) (*empty.Empty, error) {
+	e := &empty.Empty{}
+	if len(r.Id) == 0 {
+		return e, aphgrpc.HandleInvalidParamError(
+			ctx, errors.New("stock id is required"),
+		)
+	}
+	ctx, cancel := s.withTimeout(ctx, WriteTimeoutParam)
+	defer cancel()
+	if err := s.repo.PurgeStock(
+		ctx, r.Id, actorFromContext(ctx), r.Cascade,
+	); err != nil {
+		return e, handleError(ctx, err, handlePurgeError)
+	}
+	return e, nil
+}
+
+func handlePurgeError(ctx context.Context, err error) error {
+	if errors.Is(err, repository.ErrStockInUse) ||
+		errors.Is(err, repository.ErrStockNotDeleted) {
+		grpc.SetTrailer(ctx, aphgrpc.ErrDatabaseDelete)
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return handleRemoveError(ctx, err)
+}
+
+// handleRemoveError reports a stock that does not exist, or is already
+// removed, as not found
+func handleRemoveError(ctx context.Context, err error) error {
+	if errors.Is(err, repository.ErrStockNotFound) {
+		return aphgrpc.HandleNotFoundError(ctx, err)
+	}
+	return aphgrpc.HandleDeleteError(ctx, err)
+}
+
+func handleRestoreError(ctx context.Context, err error) error {
+	if errors.Is(err, repository.ErrStockNotFound) {
+		return aphgrpc.HandleNotFoundError(ctx, err)
+	}
+	return aphgrpc.HandleUpdateError(ctx, err)
+}
+
+// handleEditError reports an edit of a stock that does not exist or is
+// removed as not found
+func handleEditError(ctx context.Context, err error) error {
+	if errors.Is(err, repository.ErrStockNotFound) {
+		return aphgrpc.HandleNotFoundError(ctx, err)
+	}
+	if errors.Is(err, repository.ErrRevisionMismatch) {
+		grpc.SetTrailer(ctx, aphgrpc.ErrDatabaseUpdate)
+		return status.Error(codes.Aborted, err.Error())
+	}
+	if errors.Is(err, repository.ErrParentCycle) {
+		grpc.SetTrailer(ctx, aphgrpc.ErrDatabaseUpdate)
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return aphgrpc.HandleUpdateError(ctx, err)
+}
+
+func handleInsertError(ctx context.Context, err error) error {
+	if errors.Is(err, repository.ErrParentCycle) {
+		grpc.SetTrailer(ctx, aphgrpc.ErrDatabaseInsert)
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return aphgrpc.HandleInsertError(ctx, err)
+}
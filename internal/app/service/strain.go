@@ -18,9 +18,11 @@ func (s *StockService) GetStrain(
 	if err := r.Validate(); err != nil {
 		return st, aphgrpc.HandleInvalidParamError(ctx, err)
 	}
-	m, err := s.repo.GetStrain(r.Id)
+	ctx, cancel := s.withTimeout(ctx, GetTimeoutParam)
+	defer cancel()
+	m, err := s.repo.GetStrain(ctx, r.Id, responseFields(ctx)...)
 	if err != nil {
-		return st, aphgrpc.HandleGetError(ctx, err)
+		return st, handleError(ctx, err, handleGetError)
 	}
 	if m.NotFound {
 		return st,
@@ -30,6 +32,7 @@ func (s *StockService) GetStrain(
 			)
 	}
 	st.Data = makeStrainData(m)
+	setRevisionHeader(ctx, m)
 	return st, nil
 }
 
@@ -42,13 +45,18 @@ func (s *StockService) LoadStrain(
 	if err := r.Validate(); err != nil {
 		return st, aphgrpc.HandleInvalidParamError(ctx, err)
 	}
+	if _, err := model.ParseParents(r.Data.Attributes.Parent); err != nil {
+		return st, aphgrpc.HandleInvalidParamError(ctx, err)
+	}
 	if len(r.Data.Attributes.DictyStrainProperty) == 0 {
 		r.Data.Attributes.DictyStrainProperty = s.Params["strain_term"]
 	}
 	id := r.Data.Id
-	m, err := s.repo.LoadStrain(id, r)
+	ctx, cancel := s.withTimeout(ctx, WriteTimeoutParam)
+	defer cancel()
+	m, err := s.repo.LoadStrain(ctx, id, r)
 	if err != nil {
-		return st, aphgrpc.HandleInsertError(ctx, err)
+		return st, handleError(ctx, err, handleInsertError)
 	}
 	st.Data = makeStrainData(m)
 	err = s.publisher.PublishStrain(s.Topics["stockCreate"], st)
@@ -67,12 +75,17 @@ func (s *StockService) CreateStrain(
 	if err := r.Validate(); err != nil {
 		return st, aphgrpc.HandleInvalidParamError(ctx, err)
 	}
+	if _, err := model.ParseParents(r.Data.Attributes.Parent); err != nil {
+		return st, aphgrpc.HandleInvalidParamError(ctx, err)
+	}
 	if len(r.Data.Attributes.DictyStrainProperty) == 0 {
 		r.Data.Attributes.DictyStrainProperty = s.Params["strain_term"]
 	}
-	m, err := s.repo.AddStrain(r)
+	ctx, cancel := s.withTimeout(ctx, WriteTimeoutParam)
+	defer cancel()
+	m, err := s.repo.AddStrain(ctx, r)
 	if err != nil {
-		return st, aphgrpc.HandleInsertError(ctx, err)
+		return st, handleError(ctx, err, handleInsertError)
 	}
 	st.Data = makeStrainData(m)
 	err = s.publisher.PublishStrain(s.Topics["stockCreate"], st)
@@ -91,9 +104,15 @@ func (s *StockService) UpdateStrain(
 	if err := r.Validate(); err != nil {
 		return st, aphgrpc.HandleInvalidParamError(ctx, err)
 	}
-	m, err := s.repo.EditStrain(r)
+	parents, replace, err := model.ParseParentUpdate(r.Data.Attributes.Parent)
 	if err != nil {
-		return st, aphgrpc.HandleUpdateError(ctx, err)
+		return st, aphgrpc.HandleInvalidParamError(ctx, err)
+	}
+	ctx, cancel := s.withTimeout(ctx, WriteTimeoutParam)
+	defer cancel()
+	m, err := s.repo.EditStrain(ctx, r, expectedRevision(ctx))
+	if err != nil {
+		return st, handleError(ctx, err, handleEditError)
 	}
 	if m.NotFound {
 		return st,
@@ -104,6 +123,10 @@ func (s *StockService) UpdateStrain(
 	}
 	st.Data = makeStrainData(m)
 	st.Data.Attributes.DictyStrainProperty = ""
+	if replace && len(parents) == 0 { // the event tells about the removal
+		st.Data.Attributes.Parent = model.NoParent
+	}
+	setRevisionHeader(ctx, m)
 	err = s.publisher.PublishStrain(s.Topics["stockUpdate"], st)
 	if err != nil {
 		return st, aphgrpc.HandleMessagingPubError(ctx, err)
@@ -120,9 +143,11 @@ func (s *StockService) ListStrainsByIds(
 	if err := r.Validate(); err != nil {
 		return sl, aphgrpc.HandleInvalidParamError(ctx, err)
 	}
-	mc, err := s.repo.ListStrainsByIds(r)
+	ctx, cancel := s.withTimeout(ctx, ListTimeoutParam)
+	defer cancel()
+	mc, err := s.repo.ListStrainsByIds(ctx, r, responseFields(ctx)...)
 	if err != nil {
-		return sl, aphgrpc.HandleGetError(ctx, err)
+		return sl, handleError(ctx, err, handleGetError)
 	}
 	if len(mc) == 0 {
 		return sl,
@@ -140,28 +165,23 @@ func (s *StockService) ListStrains(
 	ctx context.Context,
 	param *stock.StockParameters,
 ) (*stock.StrainCollection, error) {
-	limit := limitVal(param.Limit)
+	limit := s.pageLimit(param.Limit)
 	scn := &stock.StrainCollection{Meta: &stock.Meta{Limit: limit}}
-	mc, err := stockModelList(&modelListParams{
+	ctx, cancel := s.withTimeout(ctx, ListTimeoutParam)
+	defer cancel()
+	page, err := stockModelList(&modelListParams{
 		ctx:         ctx,
 		stockParams: param,
 		limit:       limit,
 		fn:          s.repo.ListStrains,
+		countFn:     s.repo.CountStrains,
 	})
 	if err != nil {
 		return scn, err
 	}
-	sdata := strainModelToCollectionSlice(mc)
-	if len(sdata) < int(limit)-2 { // fewer results than limit
-		scn.Data = sdata
-		scn.Meta.Total = int64(len(sdata))
-		return scn, nil
-	}
-	scn.Data = sdata[:len(sdata)-1]
-	scn.Meta.NextCursor = genNextCursorVal(
-		sdata[len(sdata)-1].Attributes.CreatedAt,
-	)
-	scn.Meta.Total = int64(len(sdata))
+	scn.Data = strainModelToCollectionSlice(page.docs)
+	scn.Meta.Total = page.total
+	scn.Meta.NextCursor = page.nextCursor
 	return scn, nil
 }
 
@@ -201,8 +221,8 @@ func strainModelToListSlice(mc []*model.StockDoc) []*stock.StrainList_Data {
 
 func makeStrainAttributes(m *model.StockDoc) *stock.StrainAttributes {
 	return &stock.StrainAttributes{
-		CreatedAt:           aphgrpc.TimestampProto(m.CreatedAt),
-		UpdatedAt:           aphgrpc.TimestampProto(m.UpdatedAt),
+		CreatedAt:           timestampProto(m.CreatedAt),
+		UpdatedAt:           timestampProto(m.UpdatedAt),
 		CreatedBy:           m.CreatedBy,
 		UpdatedBy:           m.UpdatedBy,
 		Summary:             m.Summary,
@@ -214,7 +234,7 @@ func makeStrainAttributes(m *model.StockDoc) *stock.StrainAttributes {
 		Label:               m.StrainProperties.Label,
 		Species:             m.StrainProperties.Species,
 		Plasmid:             m.StrainProperties.Plasmid,
-		Parent:              m.StrainProperties.Parent,
+		Parent:              model.FormatParents(m.StrainProperties.ParentList()),
 		Names:               m.StrainProperties.Names,
 		DictyStrainProperty: m.StrainProperties.DictyStrainProperty,
 	}
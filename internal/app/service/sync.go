@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+
+	"github.com/dictyBase/aphgrpc"
+	"github.com/dictyBase/modware-stock/internal/api/stockext"
+	"github.com/dictyBase/modware-stock/internal/model"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// changeSort is the sort order of the cursors of the changed stocks
+const changeSort = "changes"
+
+// SyncStocks lists the strains and plasmids that were created, updated or
+// removed since a watermark, ordered by the time of their change and then
+// by their key. The removed stocks are given as tombstones.
+func (s *StockService) SyncStocks(
+	ctx context.Context,
+	r *stockext.StockSyncParameters,
+) (*stockext.StockChangeCollection, error) {
+	limit := s.pageLimit(r.Limit)
+	sc := &stockext.StockChangeCollection{
+		Data:       []*stockext.StockChange{},
+		NextCursor: r.Cursor,
+		Limit:      limit,
+	}
+	p := &model.ChangeParams{Limit: limit}
+	if r.Since != nil {
+		if err := r.Since.CheckValid(); err != nil {
+			return sc, aphgrpc.HandleInvalidParamError(ctx, err)
+		}
+		p.Since = r.Since.AsTime()
+	}
+	if len(r.Cursor) > 0 {
+		cursor, err := decodeCursor(r.Cursor, changeSort)
+		if err != nil {
+			return sc, aphgrpc.HandleInvalidParamError(ctx, err)
+		}
+		p.Cursor = cursor
+	}
+	ctx, cancel := s.withTimeout(ctx, ListTimeoutParam)
+	defer cancel()
+	changes, err := s.repo.ListStockChanges(ctx, p)
+	if err != nil {
+		return sc, handleError(ctx, err, handleListError)
+	}
+	if len(changes) == 0 {
+		return sc, nil
+	}
+	if len(changes) > int(limit) {
+		changes = changes[:limit]
+		sc.HasMore = true
+	}
+	last := changes[len(changes)-1]
+	sc.Data = changeSlice(changes)
+	sc.NextCursor = encodeCursorAt(last.SortValues, last.Key, changeSort, false)
+	return sc, nil
+}
+
+func changeSlice(changes []*model.StockChange) []*stockext.StockChange {
+	scs := make([]*stockext.StockChange, 0, len(changes))
+	for _, c := range changes {
+		sc := &stockext.StockChange{
+			Id:        c.Key,
+			Type:      c.Type,
+			ChangedAt: timestamppb.New(c.ChangedAt),
+			Deleted:   c.Deleted,
+		}
+		if c.Stock != nil {
+			sc.Stock = makeStock(c.Stock)
+		}
+		scs = append(scs, sc)
+	}
+	return scs
+}
@@ -2,18 +2,73 @@ package validate
 
 import (
 	"fmt"
+	"strings"
 
+	"github.com/dictyBase/modware-stock/internal/lineage"
+	"github.com/dictyBase/modware-stock/internal/model"
 	"github.com/urfave/cli"
 )
 
 func ValidateServerArgs(c *cli.Context) error {
-	for _, p := range []string{
+	return validateArgs(c, []string{
 		"arangodb-pass",
 		"arangodb-database",
 		"arangodb-user",
 		"nats-host",
 		"nats-port",
-	} {
+	})
+}
+
+func ValidateDbArgs(c *cli.Context) error {
+	return validateArgs(c, []string{
+		"arangodb-pass",
+		"arangodb-database",
+		"arangodb-user",
+	})
+}
+
+func ValidateLineageExportArgs(c *cli.Context) error {
+	if err := ValidateDbArgs(c); err != nil {
+		return err
+	}
+	if err := validateArgs(c, []string{"id"}); err != nil {
+		return err
+	}
+	for _, rel := range c.StringSlice("relationship") {
+		if !model.IsParentRelationship(rel) {
+			return cli.NewExitError(
+				fmt.Sprintf(
+					"unsupported relationship %s, valid relationships are %s",
+					rel, strings.Join(model.ParentRelationships, ", "),
+				),
+				2,
+			)
+		}
+	}
+	if !lineage.IsFormat(c.String("format")) {
+		return cli.NewExitError(
+			fmt.Sprintf(
+				"unsupported format %s, valid formats are %s",
+				c.String("format"), strings.Join(lineage.Formats, ", "),
+			),
+			2,
+		)
+	}
+	switch c.String("direction") {
+	case model.LineageAncestors, model.LineageDescendants:
+		return nil
+	}
+	return cli.NewExitError(
+		fmt.Sprintf(
+			"unsupported direction %s, valid directions are %s and %s",
+			c.String("direction"), model.LineageAncestors, model.LineageDescendants,
+		),
+		2,
+	)
+}
+
+func validateArgs(c *cli.Context, args []string) error {
+	for _, p := range args {
 		if len(c.String(p)) == 0 {
 			return cli.NewExitError(
 				fmt.Sprintf("argument %s is missing", p),
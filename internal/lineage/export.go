@@ -0,0 +1,188 @@
+// Package lineage exports the lineage of a strain for drawing its
+// pedigree
+package lineage
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/dictyBase/modware-stock/internal/model"
+)
+
+// Formats of the exported lineage
+const (
+	// DOT is a Graphviz directed graph of every strain once, with an edge
+	// from every parent to its child
+	DOT = "dot"
+	// Newick is a tree in the Newick format, a strain reached through
+	// more than one relative is repeated without its relatives
+	Newick = "newick"
+	// JSON is a nested JSON tree
+	JSON = "json"
+)
+
+// Formats are the formats a lineage could be exported in
+var Formats = []string{DOT, Newick, JSON}
+
+var contentTypes = map[string]string{
+	DOT:    "text/vnd.graphviz",
+	Newick: "text/x-nh",
+	JSON:   "application/json",
+}
+
+// Tree is a strain along with its relatives of the next generation, the
+// children of a descendant tree and the parents of an ancestor tree. A
+// strain that is reached through more than one relative is only given
+// with its relatives the first time, it is a reference elsewhere, so the
+// tree grows with the size of the lineage.
+type Tree struct {
+	ID                  string `json:"id"`
+	Label               string `json:"label"`
+	DictyStrainProperty string `json:"dicty_strain_property"`
+	// Relationship is the relationship of the parent edge that leads to
+	// the strain, the root has none
+	Relationship string `json:"relationship,omitempty"`
+	// Ref tells that the relatives of the strain are given where it
+	// is first reached
+	Ref      bool    `json:"ref,omitempty"`
+	Children []*Tree `json:"children,omitempty"`
+}
+
+// IsFormat tells if the lineage could be exported in the format
+func IsFormat(format string) bool {
+	_, ok := contentTypes[format]
+	return ok
+}
+
+// ContentType gives the media type of the format
+func ContentType(format string) string {
+	return contentTypes[format]
+}
+
+// Export gives the lineage in the format
+func Export(g *model.LineageGraph, format string) ([]byte, error) {
+	switch format {
+	case DOT:
+		return []byte(exportDOT(g)), nil
+	case Newick:
+		return []byte(exportNewick(BuildTree(g)) + ";\n"), nil
+	case JSON:
+		return json.MarshalIndent(BuildTree(g), "", "  ")
+	}
+	return nil, fmt.Errorf(
+		"unsupported format %s, valid formats are %s",
+		format, strings.Join(Formats, ", "),
+	)
+}
+
+type relative struct {
+	id, relationship string
+}
+
+// BuildTree gives the tree of the lineage from its root, visiting the
+// relatives depth first in the order of their ids. The edges that lead
+// back to a strain of the same branch are left out, so a cycle of parents
+// does not make an endless tree.
+func BuildTree(g *model.LineageGraph) *Tree {
+	nodes := make(map[string]*model.LineageNode)
+	for _, n := range g.Nodes {
+		nodes[n.ID] = n
+	}
+	relatives := make(map[string][]relative)
+	for _, e := range g.Edges {
+		if g.Direction == model.LineageAncestors {
+			relatives[e.Child] = append(
+				relatives[e.Child], relative{e.Parent, e.Relationship},
+			)
+			continue
+		}
+		relatives[e.Parent] = append(
+			relatives[e.Parent], relative{e.Child, e.Relationship},
+		)
+	}
+	for _, rl := range relatives {
+		sort.Slice(rl, func(i, j int) bool { return rl[i].id < rl[j].id })
+	}
+	branch := make(map[string]bool)
+	built := make(map[string]bool)
+	var build func(string, string) *Tree
+	build = func(id, relationship string) *Tree {
+		t := &Tree{ID: id, Relationship: relationship}
+		if n, ok := nodes[id]; ok {
+			t.Label = n.Label
+			t.DictyStrainProperty = n.DictyStrainProperty
+		}
+		if built[id] {
+			t.Ref = true
+			return t
+		}
+		built[id] = true
+		branch[id] = true
+		for _, r := range relatives[id] {
+			if branch[r.id] {
+				continue
+			}
+			t.Children = append(t.Children, build(r.id, r.relationship))
+		}
+		branch[id] = false
+		return t
+	}
+	return build(g.Root, "")
+}
+
+func exportDOT(g *model.LineageGraph) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "digraph %s {\n", dotQuote(g.Root+" "+g.Direction))
+	b.WriteString("\tnode [shape=box];\n")
+	for _, n := range g.Nodes {
+		fmt.Fprintf(
+			&b, "\t%s [label=%s];\n",
+			dotQuote(n.ID),
+			dotQuote(strings.Join(nodeLabel(n.ID, n.Label, n.DictyStrainProperty), "\n")),
+		)
+	}
+	for _, e := range g.Edges {
+		fmt.Fprintf(
+			&b, "\t%s -> %s [label=%s];\n",
+			dotQuote(e.Parent), dotQuote(e.Child), dotQuote(e.Relationship),
+		)
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+func exportNewick(t *Tree) string {
+	label := newickQuote(
+		strings.Join(nodeLabel(t.ID, t.Label, t.DictyStrainProperty), "|"),
+	)
+	if len(t.Children) == 0 {
+		return label
+	}
+	children := make([]string, 0, len(t.Children))
+	for _, c := range t.Children {
+		children = append(children, exportNewick(c))
+	}
+	return "(" + strings.Join(children, ",") + ")" + label
+}
+
+// nodeLabel gives the parts of the label of a strain that are present
+func nodeLabel(parts ...string) []string {
+	label := make([]string, 0, len(parts))
+	for _, p := range parts {
+		if len(p) > 0 {
+			label = append(label, p)
+		}
+	}
+	return label
+}
+
+func dotQuote(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+	return `"` + r.Replace(s) + `"`
+}
+
+func newickQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
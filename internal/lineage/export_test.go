@@ -0,0 +1,136 @@
+package lineage
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/dictyBase/modware-stock/internal/model"
+	"github.com/stretchr/testify/require"
+)
+
+func testGraph(direction string) *model.LineageGraph {
+	return &model.LineageGraph{
+		Root:      "DBS0236123",
+		Direction: direction,
+		Nodes: []*model.LineageNode{
+			{ID: "DBS0236123", Label: "AX4", DictyStrainProperty: "general strain"},
+			{ID: "DBS0236124", Label: "yS13", DictyStrainProperty: "REMI-seq"},
+			{ID: "DBS0236125", Label: `sp"1'`},
+			{ID: "DBS0236126", Label: "yS14", DictyStrainProperty: "general strain"},
+		},
+		Edges: []*model.LineageEdge{
+			{Parent: "DBS0236123", Child: "DBS0236124", Relationship: model.DerivedFrom},
+			{Parent: "DBS0236123", Child: "DBS0236125", Relationship: model.CrossedFrom},
+			{Parent: "DBS0236124", Child: "DBS0236126", Relationship: model.CrossedFrom},
+			{Parent: "DBS0236125", Child: "DBS0236126", Relationship: model.CrossedFrom},
+		},
+	}
+}
+
+func TestExportDOT(t *testing.T) {
+	t.Parallel()
+	assert := require.New(t)
+	out, err := Export(testGraph(model.LineageDescendants), DOT)
+	assert.NoError(err, "expect no error from exporting dot")
+	dot := string(out)
+	assert.Contains(dot, `digraph "DBS0236123 descendants" {`)
+	assert.Contains(
+		dot, `"DBS0236123" [label="DBS0236123\nAX4\ngeneral strain"];`,
+		"should label the strain with its id, label and property",
+	)
+	assert.Contains(
+		dot, `"DBS0236125" [label="DBS0236125\nsp\"1'"];`,
+		"should escape the quotes of the label",
+	)
+	assert.Contains(
+		dot, `"DBS0236125" -> "DBS0236126" [label="crossed_from"];`,
+		"should have an edge from the parent to the child",
+	)
+}
+
+func TestExportNewick(t *testing.T) {
+	t.Parallel()
+	assert := require.New(t)
+	out, err := Export(testGraph(model.LineageDescendants), Newick)
+	assert.NoError(err, "expect no error from exporting newick")
+	assert.Equal(
+		"(('DBS0236126|yS14|general strain')'DBS0236124|yS13|REMI-seq',"+
+			"('DBS0236126|yS14|general strain')'DBS0236125|sp\"1''')"+
+			"'DBS0236123|AX4|general strain';\n",
+		string(out),
+		"should repeat the strain under both of its parents",
+	)
+}
+
+func TestExportJSON(t *testing.T) {
+	t.Parallel()
+	assert := require.New(t)
+	out, err := Export(testGraph(model.LineageAncestors), JSON)
+	assert.NoError(err, "expect no error from exporting json")
+	tree := &Tree{}
+	assert.NoError(json.Unmarshal(out, tree), "expect json tree")
+	assert.Equal("DBS0236123", tree.ID, "should start from the root")
+	assert.Empty(tree.Children, "should not have any ancestor of the root")
+	g := testGraph(model.LineageAncestors)
+	g.Root = "DBS0236126"
+	tree = BuildTree(g)
+	assert.Len(tree.Children, 2, "should have both the parents")
+	assert.Equal("DBS0236124", tree.Children[0].ID, "should match the parent")
+	assert.Equal(model.CrossedFrom, tree.Children[0].Relationship)
+	assert.Equal("DBS0236123", tree.Children[0].Children[0].ID)
+	assert.Equal(
+		"general strain", tree.Children[0].Children[0].DictyStrainProperty,
+		"should have the property of the grandparent",
+	)
+	_, err = Export(g, "svg")
+	assert.Error(err, "expect error from unsupported format")
+}
+
+func TestBuildTreeWithCycle(t *testing.T) {
+	t.Parallel()
+	assert := require.New(t)
+	tree := BuildTree(&model.LineageGraph{
+		Root:      "A",
+		Direction: model.LineageDescendants,
+		Edges: []*model.LineageEdge{
+			{Parent: "A", Child: "B"},
+			{Parent: "B", Child: "A"},
+		},
+	})
+	assert.Len(tree.Children, 1, "should have the child")
+	assert.Empty(tree.Children[0].Children, "should leave out the cycle")
+}
+
+func TestBuildTreeWithSharedStrains(t *testing.T) {
+	t.Parallel()
+	assert := require.New(t)
+	tree := BuildTree(testGraph(model.LineageDescendants))
+	assert.False(tree.Children[0].Children[0].Ref, "should give the strain first in full")
+	assert.True(tree.Children[1].Children[0].Ref, "should refer to the strain again")
+	// every generation of the ladder is the child of both the strains of
+	// the one before
+	g := &model.LineageGraph{Root: "A0", Direction: model.LineageDescendants}
+	for i := 0; i < 30; i++ {
+		for _, p := range []string{"A", "B"} {
+			for _, c := range []string{"A", "B"} {
+				g.Edges = append(g.Edges, &model.LineageEdge{
+					Parent: fmt.Sprintf("%s%d", p, i),
+					Child:  fmt.Sprintf("%s%d", c, i+1),
+				})
+			}
+		}
+	}
+	assert.LessOrEqual(
+		countTree(BuildTree(g)), len(g.Edges)+1,
+		"should not repeat the shared subtrees",
+	)
+}
+
+func countTree(t *Tree) int {
+	n := 1
+	for _, c := range t.Children {
+		n += countTree(c)
+	}
+	return n
+}
@@ -1,6 +1,8 @@
 package model
 
 import (
+	"fmt"
+	"strings"
 	"time"
 
 	driver "github.com/arangodb/go-driver"
@@ -14,6 +16,22 @@ const (
 	Failed
 )
 
+const revisionSeparator = ":"
+
+// Kinds of change recorded in a stock revision
+const (
+	RevisionCreate  = "create"
+	RevisionLoad    = "load"
+	RevisionUpdate  = "update"
+	RevisionDelete  = "delete"
+	RevisionRestore = "restore"
+	RevisionPurge   = "purge"
+	RevisionRevert  = "revert"
+	// RevisionBaseline is the first revision of a stock that was there
+	// before the revisions were kept
+	RevisionBaseline = "baseline"
+)
+
 // StockDoc is the data structure for biological stocks
 type StockDoc struct {
 	driver.DocumentMeta
@@ -21,6 +39,8 @@ type StockDoc struct {
 	UpdatedAt         time.Time          `json:"updated_at"`
 	CreatedBy         string             `json:"created_by"`
 	UpdatedBy         string             `json:"updated_by"`
+	DeletedAt         *time.Time         `json:"deleted_at,omitempty"`
+	DeletedBy         string             `json:"deleted_by,omitempty"`
 	StockID           string             `json:"stock_id"`
 	Summary           string             `json:"summary,omitempty"`
 	EditableSummary   string             `json:"editable_summary,omitempty"`
@@ -30,17 +50,203 @@ type StockDoc struct {
 	Publications      []string           `json:"publications,omitempty"`
 	StrainProperties  *StrainProperties  `json:"strain_properties,omitempty"`
 	PlasmidProperties *PlasmidProperties `json:"plasmid_properties,omitempty"`
-	NotFound          bool
+	PropRev           string             `json:"prop_rev,omitempty"`
+	SortValues        []interface{}      `json:"sort_values,omitempty"`
+	NotFound          bool               `json:"-"`
+}
+
+// Revision gives the combined revision of the stock and its stockprop
+// document. It changes whenever any of the two documents is modified.
+func (m *StockDoc) Revision() string {
+	if len(m.Rev) == 0 {
+		return ""
+	}
+	return m.Rev + revisionSeparator + m.PropRev
+}
+
+// SplitRevision separates a combined revision into the revisions of the
+// stock and the stockprop document
+func SplitRevision(rev string) (string, string, bool) {
+	return strings.Cut(rev, revisionSeparator)
+}
+
+// SortKey is a field that a list of stocks is sorted by
+type SortKey struct {
+	Field      string
+	Descending bool
+}
+
+// StockCursor is the position of a stock in a sorted list, the key orders
+// the stocks with the same values of all sort fields. Without any key, the
+// list includes all stocks with the cursor values.
+type StockCursor struct {
+	// Values are the values of the sort fields at the cursor, in the
+	// order of the sort keys
+	Values []interface{}
+	Key    string
+	// Backward lists the stocks that come before the cursor, the
+	// nearest one first
+	Backward bool
+}
+
+// ListParams selects a page from a list of stocks
+type ListParams struct {
+	// Cursor is the position of the last stock of the previous page,
+	// the page starts from the beginning without it
+	Cursor *StockCursor
+	Limit  int64
+	Filter string
+	// Sort lists the sort keys in order of precedence, the stocks are
+	// sorted by the most recently created first without any
+	Sort []SortKey
+	// Fields limits the stocks to the selected attributes, all but the
+	// parent and the ontology term are listed without any
+	Fields []string
+}
+
+// StockRevision is the data structure for an immutable record of a change
+// made to a stock
+type StockRevision struct {
+	driver.DocumentMeta
+	StockID   string    `json:"stock_id"`
+	Action    string    `json:"action"`
+	Actor     string    `json:"actor"`
+	CreatedAt time.Time `json:"created_at"`
+	Before    *StockDoc `json:"before,omitempty"`
+	After     *StockDoc `json:"after,omitempty"`
+	// SortValues are the values a list of revisions is sorted by
+	SortValues []interface{} `json:"sort_values,omitempty"`
 }
 
 // StrainProperties is the data structure for strain properties
 type StrainProperties struct {
-	DictyStrainProperty string   `json:"dicty_strain_property"`
-	Label               string   `json:"label"`
-	Species             string   `json:"species"`
-	Plasmid             string   `json:"plasmid,omitempty"`
-	Parent              string   `json:"parent,omitempty"`
-	Names               []string `json:"names,omitempty"`
+	DictyStrainProperty string `json:"dicty_strain_property"`
+	Label               string `json:"label"`
+	Species             string `json:"species"`
+	Plasmid             string `json:"plasmid,omitempty"`
+	// Parent is the id of the first of the parents
+	Parent  string          `json:"parent,omitempty"`
+	Parents []*StrainParent `json:"parents,omitempty"`
+	Names   []string        `json:"names,omitempty"`
+}
+
+// ParentList gives the parents of the strain. The revisions recorded
+// before a strain could have more than one parent only have the Parent,
+// which is taken to be derived from.
+func (sp *StrainProperties) ParentList() []*StrainParent {
+	if len(sp.Parents) > 0 || len(sp.Parent) == 0 {
+		return sp.Parents
+	}
+	return []*StrainParent{{ID: sp.Parent, Relationship: DerivedFrom}}
+}
+
+// Relationships of a strain to its parents
+const (
+	// DerivedFrom is the relationship of a parent given without any
+	DerivedFrom     = "derived_from"
+	CrossedFrom     = "crossed_from"
+	ParasexualFrom  = "parasexual_from"
+	TransformedFrom = "transformed_from"
+	SelectedFrom    = "selected_from"
+)
+
+// ParentRelationships are the relationships a strain could have to its
+// parents
+var ParentRelationships = []string{
+	DerivedFrom, CrossedFrom, ParasexualFrom, TransformedFrom, SelectedFrom,
+}
+
+// StrainParent is a parent of a strain along with the way the strain was
+// made from it
+type StrainParent struct {
+	ID           string `json:"id"`
+	Relationship string `json:"relationship"`
+}
+
+const (
+	parentSeparator       = ","
+	relationshipSeparator = ":"
+)
+
+// NoParent is given as the parent of a strain update to remove all of
+// its parents, an update without any parent leaves them unchanged
+const NoParent = "none"
+
+// ParseParents reads the parents of a strain from a comma separated list,
+// every parent is either a stock id or a stock id and its relationship
+// separated by a colon, as in DBS0236123:crossed_from,DBS0236124:crossed_from.
+// A parent without any relationship is derived from.
+func ParseParents(spec string) ([]*StrainParent, error) {
+	var parents []*StrainParent
+	if len(strings.TrimSpace(spec)) == 0 {
+		return parents, nil
+	}
+	seen := make(map[string]bool)
+	for _, entry := range strings.Split(spec, parentSeparator) {
+		id, rel, found := strings.Cut(strings.TrimSpace(entry), relationshipSeparator)
+		id, rel = strings.TrimSpace(id), strings.TrimSpace(rel)
+		if !found {
+			rel = DerivedFrom
+		}
+		if len(id) == 0 {
+			return nil, fmt.Errorf("parent %q has no stock id", entry)
+		}
+		if id == NoParent {
+			return nil, fmt.Errorf(
+				"%s could only be given alone to remove the parents", NoParent,
+			)
+		}
+		if !IsParentRelationship(rel) {
+			return nil, fmt.Errorf(
+				"unsupported relationship %q of parent %s, valid relationships are %s",
+				rel, id, strings.Join(ParentRelationships, ", "),
+			)
+		}
+		if seen[id] {
+			return nil, fmt.Errorf("parent %s is repeated", id)
+		}
+		seen[id] = true
+		parents = append(parents, &StrainParent{ID: id, Relationship: rel})
+	}
+	return parents, nil
+}
+
+// ParseParentUpdate reads the parents of a strain update, replace tells if
+// the parents of the strain are to be replaced by them. The parents are
+// removed by NoParent and left unchanged without any.
+func ParseParentUpdate(spec string) ([]*StrainParent, bool, error) {
+	if strings.TrimSpace(spec) == NoParent {
+		return []*StrainParent{}, true, nil
+	}
+	parents, err := ParseParents(spec)
+	if err != nil {
+		return nil, false, err
+	}
+	return parents, len(parents) > 0, nil
+}
+
+// FormatParents gives the list of parents read by ParseParents, the
+// derived from relationship is left out
+func FormatParents(parents []*StrainParent) string {
+	entries := make([]string, 0, len(parents))
+	for _, p := range parents {
+		if p.Relationship == DerivedFrom || len(p.Relationship) == 0 {
+			entries = append(entries, p.ID)
+			continue
+		}
+		entries = append(entries, p.ID+relationshipSeparator+p.Relationship)
+	}
+	return strings.Join(entries, parentSeparator)
+}
+
+// IsParentRelationship tells if rel is one of the ParentRelationships
+func IsParentRelationship(rel string) bool {
+	for _, r := range ParentRelationships {
+		if r == rel {
+			return true
+		}
+	}
+	return false
 }
 
 // PlasmidProperties is the data structure for plasmid properties
@@ -49,3 +255,150 @@ type PlasmidProperties struct {
 	Sequence string `json:"sequence,omitempty"`
 	Name     string `json:"name"`
 }
+
+// SearchParams selects a page of stocks that match a full text query
+type SearchParams struct {
+	Query string
+	// StockType restricts the search to either strain or plasmid, all
+	// stocks are searched without it
+	StockType string
+	// Cursor is the position of the last stock of the previous page, its
+	// only value is the relevance score of that stock
+	Cursor *StockCursor
+	Limit  int64
+}
+
+// StockSearchHit is a stock that matches a full text query
+type StockSearchHit struct {
+	Stock *StockDoc
+	// Score is the relevance of the stock to the query, the hits are
+	// ranked by it
+	Score float64
+	// Highlights are the snippets of the matching fields, keyed by the
+	// field name, with the matching words enclosed in <em> tags
+	Highlights map[string][]string
+}
+
+// StockSuggestion is a stock whose label, name or plasmid name completes
+// the text being typed
+type StockSuggestion struct {
+	ID string `json:"id"`
+	// Label is the label of a strain or the name of a plasmid
+	Label string `json:"label"`
+	Type  string `json:"type"`
+}
+
+// FilterableField is a field that a list of stocks could be filtered by
+type FilterableField struct {
+	Name string `json:"name"`
+	// Type is either string, date or array
+	Type string `json:"type"`
+	// Operators are the filter operators of the field, the ANY and ALL
+	// operators apply the operator that follows them to the elements of
+	// an array field
+	Operators []string `json:"operators"`
+}
+
+// FacetCount is the number of stocks that have a value of a facet
+type FacetCount struct {
+	// Value is empty for the stocks without any value
+	Value string `json:"value"`
+	Count int64  `json:"count"`
+}
+
+// Facet is a field of the stocks along with the counts of its distinct
+// values, the most common value first
+type Facet struct {
+	Field  string        `json:"facet"`
+	Counts []*FacetCount `json:"counts"`
+}
+
+// ChangeParams selects a page of the stocks that changed since a
+// watermark
+type ChangeParams struct {
+	// Since is the watermark, the stocks that changed at or after it
+	// are listed
+	Since time.Time
+	// Cursor is the position of the last change of the previous page,
+	// it takes the place of the watermark
+	Cursor *StockCursor
+	Limit  int64
+}
+
+// StockChange is a stock that was created, updated or removed, the
+// changes are ordered by their time and then by the stock key
+type StockChange struct {
+	Key  string `json:"key"`
+	Type string `json:"type"`
+	// ChangedAt is the time of the last update of the stock, or the
+	// time it was purged
+	ChangedAt time.Time `json:"changed_at"`
+	// Deleted marks a tombstone of a removed stock, it has no Stock
+	Deleted    bool          `json:"deleted"`
+	Stock      *StockDoc     `json:"stock,omitempty"`
+	SortValues []interface{} `json:"sort_values,omitempty"`
+}
+
+// Directions of the traversal of the lineage of a strain
+const (
+	LineageAncestors   = "ancestors"
+	LineageDescendants = "descendants"
+)
+
+// LineageParams selects a page of the ancestors or the descendants of a
+// strain
+type LineageParams struct {
+	ID string
+	// Direction is either LineageAncestors or LineageDescendants
+	Direction string
+	// Depth is the largest number of generations to traverse
+	Depth int
+	// Relationships are the relationships of the parent edges to
+	// follow, all the edges are followed without any
+	Relationships []string
+	// Cursor is the position of the last relative of the previous page,
+	// its only value is the depth of that relative
+	Cursor *StockCursor
+	Limit  int64
+	// Fields limits the relatives to the selected attributes
+	Fields []string
+}
+
+// StrainRelative is an ancestor or a descendant of a strain, the nearest
+// relatives come first
+type StrainRelative struct {
+	Strain *StockDoc `json:"strain"`
+	// Depth is the number of generations between the strains
+	Depth int `json:"depth"`
+	// Path has the stock ids of the strains that lead from the strain
+	// to its relative, both of them included
+	Path []string `json:"path"`
+	// Relationships are the relationships of the parent edges along
+	// the path
+	Relationships []string `json:"relationships"`
+}
+
+// LineageNode is a strain of the lineage graph
+type LineageNode struct {
+	ID                  string `json:"id"`
+	Label               string `json:"label"`
+	DictyStrainProperty string `json:"dicty_strain_property"`
+}
+
+// LineageEdge is a parent edge of the lineage graph
+type LineageEdge struct {
+	Parent       string `json:"parent"`
+	Child        string `json:"child"`
+	Relationship string `json:"relationship"`
+}
+
+// LineageGraph has the ancestors or the descendants of a strain along with
+// the parent edges between them
+type LineageGraph struct {
+	// Root is the id of the strain the lineage starts from
+	Root string `json:"root"`
+	// Direction is either LineageAncestors or LineageDescendants
+	Direction string         `json:"direction"`
+	Nodes     []*LineageNode `json:"nodes"`
+	Edges     []*LineageEdge `json:"edges"`
+}
@@ -1,12 +1,15 @@
 package arangodb
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"strings"
+	"time"
 
 	"github.com/cockroachdb/errors"
 	manager "github.com/dictyBase/arangomanager"
+	"github.com/dictyBase/go-obograph/graph"
 	ontostorage "github.com/dictyBase/go-obograph/storage"
 	ontoarango "github.com/dictyBase/go-obograph/storage/arangodb"
 	"github.com/dictyBase/modware-stock/internal/repository"
@@ -15,11 +18,13 @@ import (
 )
 
 type arangorepository struct {
-	ontoc      *ontoarango.OntoCollection
-	sess       *manager.Session
-	database   *manager.Database
-	stockc     *stockc
-	strainOnto string
+	ontoc       *ontoarango.OntoCollection
+	sess        *manager.Session
+	database    *manager.Database
+	stockc      *stockc
+	strainOnto  string
+	searchView  string
+	lockTimeout time.Duration
 }
 
 // NewStockRepo acts as constructor for database
@@ -27,7 +32,13 @@ func NewStockRepo(connP *manager.ConnectParams,
 	collP *CollectionParams,
 	ontoP *ontoarango.CollectionParams,
 ) (repository.StockRepository, error) {
-	ar := &arangorepository{strainOnto: collP.StrainOntology}
+	ar := &arangorepository{
+		strainOnto:  collP.StrainOntology,
+		lockTimeout: collP.LockTimeout,
+	}
+	if ar.lockTimeout == 0 {
+		ar.lockTimeout = defaultLockTimeout
+	}
 	validate := validator.New()
 	if err := validate.Struct(collP); err != nil {
 		return ar, err
@@ -49,26 +60,26 @@ func NewStockRepo(connP *manager.ConnectParams,
 	return ar, err
 }
 
-func (ar *arangorepository) checkStock(id string) (string, error) {
-	r, err := ar.database.GetRow(
+// checkStock gives the key of the stockprop document of a stock that is
+// about to be modified. It fails with repository.ErrStockNotFound if the
+// stock does not exist or is removed.
+func (ar *arangorepository) checkStock(tx *dbTx, id string) (string, error) {
+	var propKey string
+	found, err := tx.getRow(
 		statement.StockFindIdQ,
 		map[string]interface{}{
-			"stock_collection": ar.stockc.stock.Name(),
-			"stock_prop_graph": ar.stockc.stockPropType.Name(),
-			"stock_id":         id,
-		})
+			"@stock_collection": ar.stockc.stock.Name(),
+			"stock_prop_graph":  ar.stockc.stockPropType.Name(),
+			"stock_id":          id,
+		}, &propKey)
 	if err != nil {
 		return id,
 			errors.Errorf("error in finding stock id %s %s", id, err)
 	}
-	if r.IsEmpty() {
-		return id,
-			errors.Errorf("stock id %s is absent in database", id)
-	}
-	var propKey string
-	if err := r.Read(&propKey); err != nil {
-		return id,
-			errors.Errorf("error in reading using stock id %s %s", id, err)
+	if !found {
+		return id, errors.Wrapf(
+			repository.ErrStockNotFound, "stock id %s is absent in database", id,
+		)
 	}
 	return propKey, nil
 }
@@ -109,33 +120,40 @@ func (ar *arangorepository) Dbh() *manager.Database {
 	return ar.database
 }
 
-func (ar *arangorepository) termID(term, onto string) (string, error) {
+func (ar *arangorepository) termID(
+	tx *dbTx,
+	term, onto string,
+) (string, error) {
 	var id string
-	r, err := ar.database.GetRow(
+	found, err := tx.getRow(
 		statement.StrainExistTermQ,
 		map[string]interface{}{
 			"@cv_collection":     ar.ontoc.Cv.Name(),
 			"@cvterm_collection": ar.ontoc.Term.Name(),
 			"ontology":           onto,
 			"term":               term,
-		})
+		}, &id)
 	if err != nil {
 		return id,
 			errors.Errorf("error in running obograph retrieving query %s", err)
 	}
-	if r.IsEmpty() {
+	if !found {
 		return id,
 			errors.Errorf("ontology %s and tag %s does not exist", onto, term)
 	}
-	if err := r.Read(&id); err != nil {
-		return id, errors.Errorf("error in retrieving obograph id %s", err)
-	}
 	return id, nil
 }
 
+// LoadOboJSON loads an ontology in obograph json format. The ontology
+// storage does not accept any context, so ctx is checked before each of
+// the steps that save the graph, the terms and the relationships.
 func (ar *arangorepository) LoadOboJSON(
+	ctx context.Context,
 	r io.Reader,
 ) (*ontostorage.UploadInformation, error) {
+	if err := ctx.Err(); err != nil {
+		return &ontostorage.UploadInformation{}, err
+	}
 	ds, err := ontoarango.NewDataSourceFromDb(ar.database,
 		&ontoarango.CollectionParams{
 			OboGraph:     ar.ontoc.Obog.Name(),
@@ -146,5 +164,64 @@ func (ar *arangorepository) LoadOboJSON(
 	if err != nil {
 		return &ontostorage.UploadInformation{}, err
 	}
-	return ontostorage.LoadOboJSONFromDataSource(r, ds)
+	return ontostorage.LoadOboJSONFromDataSource(
+		r, &ctxDataSource{ctx: ctx, DataSource: ds},
+	)
+}
+
+// ctxDataSource stops the loading of an ontology once ctx is done
+type ctxDataSource struct {
+	ctx context.Context
+	ontostorage.DataSource
+}
+
+func (cd *ctxDataSource) SaveOboGraphInfo(g graph.OboGraph) error {
+	if err := cd.ctx.Err(); err != nil {
+		return err
+	}
+	return cd.DataSource.SaveOboGraphInfo(g)
+}
+
+func (cd *ctxDataSource) UpdateOboGraphInfo(g graph.OboGraph) error {
+	if err := cd.ctx.Err(); err != nil {
+		return err
+	}
+	return cd.DataSource.UpdateOboGraphInfo(g)
+}
+
+func (cd *ctxDataSource) SaveTerms(g graph.OboGraph) (int, error) {
+	if err := cd.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return cd.DataSource.SaveTerms(g)
+}
+
+func (cd *ctxDataSource) UpdateTerms(g graph.OboGraph) (int, error) {
+	if err := cd.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return cd.DataSource.UpdateTerms(g)
+}
+
+func (cd *ctxDataSource) SaveOrUpdateTerms(
+	g graph.OboGraph,
+) (*ontostorage.Stats, error) {
+	if err := cd.ctx.Err(); err != nil {
+		return &ontostorage.Stats{}, err
+	}
+	return cd.DataSource.SaveOrUpdateTerms(g)
+}
+
+func (cd *ctxDataSource) SaveRelationships(g graph.OboGraph) (int, error) {
+	if err := cd.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return cd.DataSource.SaveRelationships(g)
+}
+
+func (cd *ctxDataSource) SaveNewRelationships(g graph.OboGraph) (int, error) {
+	if err := cd.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return cd.DataSource.SaveNewRelationships(g)
 }
@@ -2,6 +2,7 @@ package arangodb
 
 import (
 	"bufio"
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -68,6 +69,7 @@ func getCollectionParams() *CollectionParams {
 		StockTerm:          "stock_term",
 		StockProp:          "stockprop",
 		StockKeyGenerator:  "stock_key_generator",
+		StockRevision:      "stock_revision",
 		StockType:          "stock_type",
 		StockOntoGraph:     "stockonto",
 		ParentStrain:       "parent_strain",
@@ -75,6 +77,7 @@ func getCollectionParams() *CollectionParams {
 		Strain2ParentGraph: "strain2parent",
 		KeyOffset:          370000,
 		StrainOntology:     "dicty_strain_property",
+		SearchView:         "stock_search",
 	}
 }
 
@@ -290,25 +293,202 @@ func TestLoadOboJson(t *testing.T) {
 	fh, err := oboReader()
 	assert.NoErrorf(err, "expect no error, received %s", err)
 	defer fh.Close()
-	m, err := repo.LoadOboJSON(bufio.NewReader(fh))
+	m, err := repo.LoadOboJSON(context.Background(), bufio.NewReader(fh))
 	assert.NoErrorf(err, "expect no error, received %s", err)
 	assert.True(m.IsCreated, "should match created status")
 }
 
+// countingDataSource counts the terms it is asked to save
+type countingDataSource struct {
+	storage.DataSource
+	saves int
+}
+
+func (cd *countingDataSource) SaveTerms(g graph.OboGraph) (int, error) {
+	cd.saves++
+	return 0, nil
+}
+
+func TestCtxDataSource(t *testing.T) {
+	t.Parallel()
+	assert := require.New(t)
+	inner := &countingDataSource{}
+	ctx, cancel := context.WithCancel(context.Background())
+	cd := &ctxDataSource{ctx: ctx, DataSource: inner}
+	_, err := cd.SaveTerms(nil)
+	assert.NoError(err, "expect no error before the context is done")
+	cancel()
+	_, err = cd.SaveTerms(nil)
+	assert.ErrorIs(err, context.Canceled, "expect error once the context is done")
+	assert.Equal(1, inner.saves, "should not save after the context is done")
+}
+
 func TestRemoveStock(t *testing.T) {
 	assert, repo := setUp(t)
 	defer tearDown(repo)
 	ns := newTestStrain("george@costanza.com", General)
-	m, err := repo.AddStrain(ns)
+	m, err := repo.AddStrain(context.Background(), ns)
 	assert.NoErrorf(err, "expect no error, received %s", err)
-	err = repo.RemoveStock(m.Key)
+	err = repo.RemoveStock(context.Background(), m.Key, "art@vandelay.com")
 	assert.NoErrorf(err, "expect no error, received %s", err)
-	ne, err := repo.GetStrain(m.Key)
+	ne, err := repo.GetStrain(context.Background(), m.Key)
 	assert.NoErrorf(err, "expect no error, received %s", err)
 	assert.True(ne.NotFound, "entry should not exist")
+	dl, err := repo.ListDeletedStocks(context.Background(), nil, 10)
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	assert.Len(dl, 1, "should list one deleted stock")
+	assert.Equal(dl[0].StockID, m.StockID, "should match deleted stock id")
+	assert.Equal(
+		dl[0].DeletedBy,
+		"art@vandelay.com",
+		"should match the user who deleted the stock",
+	)
+	assert.NotNil(dl[0].DeletedAt, "should have deletion time")
+	err = repo.RestoreStock(context.Background(), m.Key, "art@vandelay.com")
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	rm, err := repo.GetStrain(context.Background(), m.Key)
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	assert.False(rm.NotFound, "entry should be restored")
+	assert.Nil(rm.DeletedAt, "should not have deletion time")
+	err = repo.RestoreStock(context.Background(), m.Key, "art@vandelay.com")
+	assert.ErrorIs(
+		err, repository.ErrStockNotFound,
+		"should not restore a stock that is not deleted",
+	)
+	err = repo.RemoveStock(context.Background(), m.Key, "art@vandelay.com")
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	err = repo.RemoveStock(context.Background(), m.Key, "art@vandelay.com")
+	assert.ErrorIs(
+		err, repository.ErrStockNotFound,
+		"should not remove a stock that is already removed",
+	)
+	_, err = repo.EditStrain(
+		context.Background(), strainParentUpdate(m.Key, model.NoParent), "",
+	)
+	assert.ErrorIs(
+		err, repository.ErrStockNotFound,
+		"should not edit a removed stock",
+	)
 	// try removing nonexistent stock
-	e := repo.RemoveStock("xyz")
-	assert.Error(e)
+	e := repo.RemoveStock(context.Background(), "xyz", "art@vandelay.com")
+	assert.ErrorIs(e, repository.ErrStockNotFound)
+}
+
+func TestPurgeStock(t *testing.T) {
+	assert, repo := setUp(t)
+	defer tearDown(repo)
+	ns := newTestStrain("george@costanza.com", General)
+	m, err := repo.AddStrain(context.Background(), ns)
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	err = repo.PurgeStock(context.Background(), m.Key, "art@vandelay.com", false)
+	assert.ErrorIs(
+		err, repository.ErrStockNotDeleted,
+		"should not purge a stock that is not removed",
+	)
+	err = repo.RemoveStock(context.Background(), m.Key, "art@vandelay.com")
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	err = repo.PurgeStock(context.Background(), m.Key, "art@vandelay.com", false)
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	ne, err := repo.GetStrain(context.Background(), m.Key)
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	assert.True(ne.NotFound, "entry should not exist")
+	count, err := repo.Dbh().CountWithParams(
+		`FOR e IN @@stock_type_collection
+			FILTER e._from == CONCAT('stock/', @id)
+			RETURN e`,
+		map[string]interface{}{
+			"@stock_type_collection": "stock_type",
+			"id":                     m.Key,
+		},
+	)
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	assert.Equal(int64(0), count, "should not leave any stock_type edge")
+	// try removing nonexistent stock
+	e := repo.PurgeStock(context.Background(), "xyz", "art@vandelay.com", false)
+	assert.ErrorIs(e, repository.ErrStockNotFound)
+}
+
+func TestPurgeDeletedStocks(t *testing.T) {
+	assert, repo := setUp(t)
+	defer tearDown(repo)
+	ids, err := createTestStrainsWithIDs(3, General, repo)
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	for _, id := range ids[:2] {
+		err := repo.RemoveStock(context.Background(), id, "art@vandelay.com")
+		assert.NoErrorf(err, "expect no error, received %s", err)
+	}
+	pids, err := repo.PurgeDeletedStocks(
+		context.Background(), time.Now().Add(-time.Hour), "art@vandelay.com",
+	)
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	assert.Empty(pids, "should not purge recently deleted stocks")
+	pids, err = repo.PurgeDeletedStocks(
+		context.Background(), time.Now().Add(time.Minute), "art@vandelay.com",
+	)
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	assert.ElementsMatch(pids, ids[:2], "should purge the deleted stocks")
+	dl, err := repo.ListDeletedStocks(context.Background(), nil, 10)
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	assert.Empty(dl, "should not have any deleted stock")
+	m, err := repo.GetStrain(context.Background(), ids[2])
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	assert.False(m.NotFound, "should keep the stock that is not deleted")
+}
+
+func TestPurgeStockWithReference(t *testing.T) {
+	assert, repo := setUp(t)
+	defer tearDown(repo)
+	pm, err := repo.AddStrain(context.Background(), newTestParentStrain("j@peterman.org"))
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	ids, err := createTestStrainsWithParent(2, General, repo, pm.StockID)
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	err = repo.RemoveStock(context.Background(), pm.StockID, "art@vandelay.com")
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	err = repo.PurgeStock(context.Background(), pm.StockID, "art@vandelay.com", false)
+	assert.Error(err, "should not remove a parent strain")
+	assert.ErrorIs(
+		err,
+		repository.ErrStockInUse,
+		"should report the stock as in use",
+	)
+	err = repo.PurgeStock(context.Background(), pm.StockID, "art@vandelay.com", true)
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	for _, id := range ids {
+		cm, err := repo.GetStrain(context.Background(), id)
+		assert.NoErrorf(err, "expect no error, received %s", err)
+		assert.False(cm.NotFound, "child strain should exist")
+		assert.Empty(
+			cm.StrainProperties.Parent,
+			"child strain should not have any parent",
+		)
+	}
+	pl, err := repo.AddPlasmid(context.Background(), newTestPlasmid("george@costanza.com"))
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	ns := newTestStrain("george@costanza.com", General)
+	ns.Data.Attributes.Plasmid = pl.StockID
+	sm, err := repo.AddStrain(context.Background(), ns)
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	err = repo.RemoveStock(context.Background(), pl.StockID, "art@vandelay.com")
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	err = repo.PurgeStock(context.Background(), pl.StockID, "art@vandelay.com", false)
+	assert.ErrorIs(
+		err,
+		repository.ErrStockInUse,
+		"should not remove a plasmid used by a strain",
+	)
+	err = repo.PurgeStock(context.Background(), pl.StockID, "art@vandelay.com", true)
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	pm2, err := repo.GetPlasmid(context.Background(), pl.StockID)
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	assert.True(pm2.NotFound, "plasmid should not exist")
+	sm2, err := repo.GetStrain(context.Background(), sm.StockID)
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	assert.Empty(sm2.StrainProperties.Plasmid, "strain should not refer to the plasmid")
+	rms, err := repo.ListStockRevisions(context.Background(), sm.StockID, nil, 10)
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	assert.Equal(model.RevisionUpdate, rms[0].Action, "should record the detachment")
+	assert.Equal(pl.StockID, rms[0].Before.StrainProperties.Plasmid)
+	assert.Empty(rms[0].After.StrainProperties.Plasmid)
 }
 
 func testModelListSort(m []*model.StockDoc, t *testing.T) {
@@ -328,6 +508,8 @@ func testModelListSort(m []*model.StockDoc, t *testing.T) {
 	}
 }
 
-func toTimestamp(t time.Time) int64 {
-	return t.UnixNano() / 1000000
+// createdCursor gives a cursor that includes all stocks created at t in
+// the default sort order
+func createdCursor(t time.Time) *model.StockCursor {
+	return &model.StockCursor{Values: []interface{}{t.UnixMilli()}}
 }
@@ -0,0 +1,145 @@
+package arangodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/dictyBase/modware-stock/internal/model"
+	"github.com/dictyBase/modware-stock/internal/repository/arangodb/statement"
+)
+
+// stockAsOf is the state of a stock after a revision, none after it is
+// purged
+type stockAsOf struct {
+	Doc *model.StockDoc `json:"doc"`
+}
+
+// GetStrainAsOf retrieves a strain as it existed at the given time. A
+// strain is not known before its first revision, so it is not found then.
+// The stocks that were there before the revisions were kept get their
+// first one from BackfillRevisions.
+func (ar *arangorepository) GetStrainAsOf(
+	ctx context.Context,
+	id string,
+	asOf time.Time,
+) (*model.StockDoc, error) {
+	m, err := ar.stockAsOf(ctx, id, asOf)
+	if err != nil {
+		return m, err
+	}
+	if m.StrainProperties == nil {
+		return &model.StockDoc{NotFound: true}, nil
+	}
+	return m, nil
+}
+
+// GetPlasmidAsOf retrieves a plasmid as it existed at the given time. A
+// plasmid is not known before its first revision, so it is not found then,
+// as with GetStrainAsOf.
+func (ar *arangorepository) GetPlasmidAsOf(
+	ctx context.Context,
+	id string,
+	asOf time.Time,
+) (*model.StockDoc, error) {
+	m, err := ar.stockAsOf(ctx, id, asOf)
+	if err != nil {
+		return m, err
+	}
+	if m.PlasmidProperties == nil {
+		return &model.StockDoc{NotFound: true}, nil
+	}
+	return m, nil
+}
+
+// ListStrainsAsOf provides a list of strains as they existed at the
+// given time, the strains without any revision until then are left out
+func (ar *arangorepository) ListStrainsAsOf(
+	ctx context.Context,
+	param *model.ListParams,
+	asOf time.Time,
+) ([]*model.StockDoc, error) {
+	filter, filterVars, err := filterClause(param.Filter, asOfPage)
+	if err != nil {
+		return []*model.StockDoc{}, err
+	}
+	bindVars := map[string]interface{}{
+		"as_of":                      asOf.UnixMilli(),
+		"limit":                      param.Limit + 1,
+		"stock_revision_collection":  ar.stockc.stockRevision.Name(),
+		"@stock_revision_collection": ar.stockc.stockRevision.Name(),
+	}
+	page, err := asOfPage.clause(param, bindVars)
+	if err != nil {
+		return []*model.StockDoc{}, err
+	}
+	mergeBindVars(bindVars, filterVars)
+	return searchRows[model.StockDoc](
+		ar.directTx(ctx),
+		fmt.Sprintf(statement.StrainListAsOf, filter, page),
+		bindVars,
+	)
+}
+
+// CountStrainsAsOf counts the strains that match the filter as they
+// existed at the given time
+func (ar *arangorepository) CountStrainsAsOf(
+	ctx context.Context,
+	filter string,
+	asOf time.Time,
+) (int64, error) {
+	clause, bindVars, err := filterClause(filter, asOfPage)
+	if err != nil {
+		return 0, err
+	}
+	bindVars["as_of"] = asOf.UnixMilli()
+	bindVars["stock_revision_collection"] = ar.stockc.stockRevision.Name()
+	bindVars["@stock_revision_collection"] = ar.stockc.stockRevision.Name()
+	var total int64
+	_, err = ar.directTx(ctx).getRow(
+		fmt.Sprintf(statement.StrainCountAsOf, clause),
+		bindVars, &total,
+	)
+	if err != nil {
+		return 0, errors.Errorf("error in counting strains %s", err)
+	}
+	return total, nil
+}
+
+// stockAsOf gives a stock as it was after its last revision at or before
+// the given time
+func (ar *arangorepository) stockAsOf(
+	ctx context.Context,
+	id string,
+	asOf time.Time,
+) (*model.StockDoc, error) {
+	sa := &stockAsOf{}
+	found, err := ar.directTx(ctx).getRow(
+		statement.StockAsOfQ,
+		map[string]interface{}{
+			"id":                         id,
+			"as_of":                      asOf.UnixMilli(),
+			"@stock_revision_collection": ar.stockc.stockRevision.Name(),
+		}, sa)
+	if err != nil {
+		return &model.StockDoc{}, errors.Errorf(
+			"error in finding revision of stock %s %s",
+			id, err,
+		)
+	}
+	m := sa.Doc
+	if !found || !existedAt(m, asOf) {
+		return &model.StockDoc{NotFound: true}, nil
+	}
+	m.DeletedAt = nil
+	m.DeletedBy = ""
+	return m, nil
+}
+
+func existedAt(m *model.StockDoc, asOf time.Time) bool {
+	if m == nil || m.CreatedAt.After(asOf) {
+		return false
+	}
+	return m.DeletedAt == nil || m.DeletedAt.After(asOf)
+}
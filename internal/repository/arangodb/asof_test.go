@@ -0,0 +1,147 @@
+package arangodb
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dictyBase/modware-stock/internal/model"
+)
+
+func TestGetStrainAsOf(t *testing.T) {
+	t.Parallel()
+	assert, repo := setUp(t)
+	defer tearDown(repo)
+	beforeCreate := time.Now().Add(-time.Minute)
+	ns := newUpdatableTestStrain("todd@gagg.com", General)
+	m, err := repo.AddStrain(context.Background(), ns)
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	time.Sleep(500 * time.Millisecond)
+	afterCreate := time.Now()
+	time.Sleep(500 * time.Millisecond)
+	us := strainUpdateInstance(ns, m)
+	_, err = repo.EditStrain(context.Background(), us, "")
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	om, err := repo.GetStrainAsOf(context.Background(), m.Key, afterCreate)
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	assert.False(om.NotFound, "strain should exist after creation")
+	assert.Equal(
+		om.StrainProperties.Label,
+		ns.Data.Attributes.Label,
+		"should match the label before update",
+	)
+	assert.Equal(
+		om.StrainProperties.DictyStrainProperty,
+		"general strain",
+		"should match ontology strain property",
+	)
+	cm, err := repo.GetStrainAsOf(context.Background(), m.Key, time.Now().Add(time.Minute))
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	assert.Equal(
+		cm.StrainProperties.Label,
+		us.Data.Attributes.Label,
+		"should match the label after update",
+	)
+	nm, err := repo.GetStrainAsOf(context.Background(), m.Key, beforeCreate)
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	assert.True(nm.NotFound, "strain should not exist before creation")
+	pm, err := repo.GetPlasmidAsOf(context.Background(), m.Key, afterCreate)
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	assert.True(pm.NotFound, "strain should not be retrieved as plasmid")
+	ls, err := repo.ListStrainsAsOf(
+		context.Background(),
+		&model.ListParams{Limit: 10},
+		afterCreate,
+	)
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	assert.Len(ls, 1, "should list one strain")
+	assert.Equal(
+		ls[0].StrainProperties.Label,
+		ns.Data.Attributes.Label,
+		"should list the strain before update",
+	)
+	els, err := repo.ListStrainsAsOf(
+		context.Background(),
+		&model.ListParams{Limit: 10},
+		beforeCreate,
+	)
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	assert.Empty(els, "should not list any strain before creation")
+}
+
+func TestGetPlasmidAsOf(t *testing.T) {
+	t.Parallel()
+	assert, repo := setUp(t)
+	defer tearDown(repo)
+	m, err := repo.AddPlasmid(context.Background(), newTestPlasmid("george@costanza.com"))
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	time.Sleep(500 * time.Millisecond)
+	afterCreate := time.Now()
+	time.Sleep(500 * time.Millisecond)
+	err = repo.RemoveStock(context.Background(), m.Key, "art@vandelay.com")
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	om, err := repo.GetPlasmidAsOf(context.Background(), m.Key, afterCreate)
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	assert.False(om.NotFound, "plasmid should exist before deletion")
+	assert.Equal(om.PlasmidProperties.Name, "p123456", "should match name")
+	dm, err := repo.GetPlasmidAsOf(context.Background(), m.Key, time.Now().Add(time.Minute))
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	assert.True(dm.NotFound, "plasmid should not exist after deletion")
+}
+
+func TestLoadedStrainAsOf(t *testing.T) {
+	t.Parallel()
+	assert, repo := setUp(t)
+	defer tearDown(repo)
+	tm, _ := time.Parse("2006-01-02 15:04:05", "2012-06-14 09:12:33")
+	m, err := repo.LoadStrain(
+		context.Background(), "DBS0900001", newTestExistingStrain(tm, "bulk"),
+	)
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	om, err := repo.GetStrainAsOf(context.Background(), m.Key, tm.AddDate(1, 0, 0))
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	assert.True(om.NotFound, "strain should not be known before its first revision")
+	cm, err := repo.GetStrainAsOf(context.Background(), m.Key, time.Now().Add(time.Minute))
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	assert.Equal("bulk", cm.StrainProperties.Label, "should match the loaded label")
+	ls, err := repo.ListStrainsAsOf(
+		context.Background(),
+		&model.ListParams{Limit: 10, Filter: "tag==general strain"},
+		time.Now().Add(time.Minute),
+	)
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	assert.Len(ls, 1, "should list the loaded strain by its strain property")
+}
+
+func TestBackfillRevisions(t *testing.T) {
+	t.Parallel()
+	assert, repo := setUp(t)
+	defer tearDown(repo)
+	ar, ok := repo.(*arangorepository)
+	assert.True(ok, "should be an arangodb repository")
+	m, err := repo.AddStrain(context.Background(), newTestStrain("todd@gagg.com", General))
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	err = ar.stockc.stockRevision.Truncate(context.Background())
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	asOf := time.Now().Add(time.Minute)
+	nm, err := repo.GetStrainAsOf(context.Background(), m.Key, asOf)
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	assert.True(nm.NotFound, "strain should not be found without any revision")
+	ids, err := repo.BackfillRevisions(context.Background(), "todd@gagg.com")
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	assert.ElementsMatch([]string{m.Key}, ids, "should backfill the strain")
+	om, err := repo.GetStrainAsOf(context.Background(), m.Key, asOf)
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	assert.False(om.NotFound, "strain should be found after backfill")
+	assert.Equal(m.StrainProperties.Label, om.StrainProperties.Label, "should match the label")
+	rms, err := repo.ListStockRevisions(context.Background(), m.Key, nil, 10)
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	assert.Len(rms, 1, "should have one revision")
+	assert.Equal(model.RevisionBaseline, rms[0].Action, "should have baseline revision")
+	total, err := repo.CountStrainsAsOf(context.Background(), "", asOf)
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	assert.Equal(int64(1), total, "should count the backfilled strain")
+	ids, err = repo.BackfillRevisions(context.Background(), "todd@gagg.com")
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	assert.Empty(ids, "should not backfill the strain again")
+}
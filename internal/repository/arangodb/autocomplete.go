@@ -0,0 +1,114 @@
+package arangodb
+
+import (
+	"context"
+	"unicode/utf8"
+
+	driver "github.com/arangodb/go-driver"
+	"github.com/dictyBase/modware-stock/internal/model"
+	"github.com/dictyBase/modware-stock/internal/repository/arangodb/statement"
+)
+
+const (
+	// normAnalyzer keeps a name as a single lower cased term, for
+	// matching its prefix or its edit distance
+	normAnalyzer = "stock_norm"
+	// ngramAnalyzer splits a lower cased name into its bigrams and
+	// trigrams, for matching the names that are similar to a misspelling
+	ngramAnalyzer = "stock_ngram"
+	// ngramThreshold is the least fraction of the n-grams of the typed
+	// text that a similar name has to share
+	ngramThreshold = 0.6
+	// suggestCandidates is the number of best ranked names taken from the
+	// search view for every suggestion, the others are of removed stocks
+	suggestCandidates = 4
+)
+
+func normAnalyzerDef() driver.ArangoSearchAnalyzerDefinition {
+	accent := false
+	return driver.ArangoSearchAnalyzerDefinition{
+		Name: normAnalyzer,
+		Type: driver.ArangoSearchAnalyzerTypeNorm,
+		Properties: driver.ArangoSearchAnalyzerProperties{
+			Locale: "en",
+			Case:   driver.ArangoSearchCaseLower,
+			Accent: &accent,
+		},
+		Features: []driver.ArangoSearchAnalyzerFeature{
+			driver.ArangoSearchAnalyzerFeatureFrequency,
+			driver.ArangoSearchAnalyzerFeatureNorm,
+		},
+	}
+}
+
+func ngramAnalyzerDef() driver.ArangoSearchAnalyzerDefinition {
+	accent, preserve := false, false
+	minGram, maxGram := int64(2), int64(3)
+	stream := driver.ArangoSearchNGramStreamUTF8
+	return driver.ArangoSearchAnalyzerDefinition{
+		Name: ngramAnalyzer,
+		Type: driver.ArangoSearchAnalyzerTypePipeline,
+		Properties: driver.ArangoSearchAnalyzerProperties{
+			Pipeline: []driver.ArangoSearchAnalyzerPipeline{
+				{
+					Type: driver.ArangoSearchAnalyzerTypeNorm,
+					Properties: driver.ArangoSearchAnalyzerProperties{
+						Locale: "en",
+						Case:   driver.ArangoSearchCaseLower,
+						Accent: &accent,
+					},
+				},
+				{
+					Type: driver.ArangoSearchAnalyzerTypeNGram,
+					Properties: driver.ArangoSearchAnalyzerProperties{
+						Min:              &minGram,
+						Max:              &maxGram,
+						PreserveOriginal: &preserve,
+						StreamType:       &stream,
+					},
+				},
+			},
+		},
+		Features: []driver.ArangoSearchAnalyzerFeature{
+			driver.ArangoSearchAnalyzerFeatureFrequency,
+			driver.ArangoSearchAnalyzerFeatureNorm,
+			driver.ArangoSearchAnalyzerFeaturePosition,
+		},
+	}
+}
+
+// typoDistance is the number of typing mistakes allowed in a text, the
+// short ones have to be typed exactly
+func typoDistance(text string) int {
+	switch n := utf8.RuneCountInString(text); {
+	case n >= 8:
+		return 2
+	case n >= 4:
+		return 1
+	}
+	return 0
+}
+
+// SuggestStocks gives the stocks whose label, names or plasmid name
+// start with or resemble the typed text, the closest first
+func (ar *arangorepository) SuggestStocks(
+	ctx context.Context,
+	text string,
+	limit int64,
+) ([]*model.StockSuggestion, error) {
+	return searchRows[model.StockSuggestion](
+		ar.directTx(ctx),
+		statement.StockSuggest,
+		map[string]interface{}{
+			"@search_view":           ar.searchView,
+			"@stock_type_collection": ar.stockc.stockType.Name(),
+			"norm_analyzer":          normAnalyzer,
+			"ngram_analyzer":         ngramAnalyzer,
+			"query":                  text,
+			"distance":               typoDistance(text),
+			"threshold":              ngramThreshold,
+			"candidates":             limit * suggestCandidates,
+			"limit":                  limit,
+		},
+	)
+}
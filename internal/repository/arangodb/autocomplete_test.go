@@ -0,0 +1,70 @@
+package arangodb
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dictyBase/modware-stock/internal/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTypoDistance(t *testing.T) {
+	t.Parallel()
+	assert := require.New(t)
+	assert.Equal(0, typoDistance("AX4"), "should match short text exactly")
+	assert.Equal(1, typoDistance("carA-"), "should allow a single typo")
+	assert.Equal(2, typoDistance("carA-/carB-"), "should allow two typos")
+}
+
+func TestSuggestStocks(t *testing.T) {
+	t.Parallel()
+	assert, repo := setUp(t)
+	defer tearDown(repo)
+	labels := []string{"AX4", "AX2", "carA-/carB-", "carA-", "DH1"}
+	ids := make(map[string]string)
+	for _, l := range labels {
+		ns := newTestStrain("kramer@costanza.com", General)
+		ns.Data.Attributes.Label = l
+		ns.Data.Attributes.Names = []string{"gammaS13"}
+		m, err := repo.AddStrain(context.Background(), ns)
+		assert.NoError(err, "expect no error from adding strain")
+		ids[l] = m.StockID
+	}
+	np := newTestPlasmid("kramer@costanza.com")
+	np.Data.Attributes.Name = "pDXA-GFP2"
+	pm, err := repo.AddPlasmid(context.Background(), np)
+	assert.NoError(err, "expect no error from adding plasmid")
+	// the view is updated in the background after the commit
+	var sgs []*model.StockSuggestion
+	assert.Eventually(func() bool {
+		sgs, err = repo.SuggestStocks(context.Background(), "pdxa", 10)
+		return err == nil && len(sgs) > 0
+	}, 10*time.Second, 200*time.Millisecond, "should complete the plasmid name")
+	assert.Equal(pm.StockID, sgs[0].ID, "should suggest the plasmid")
+	assert.Equal("pDXA-GFP2", sgs[0].Label, "should give the plasmid name")
+	assert.Equal("plasmid", sgs[0].Type, "should give the stock type")
+	sgs, err = repo.SuggestStocks(context.Background(), "ax", 10)
+	assert.NoError(err, "expect no error from completing prefix")
+	assert.Len(sgs, 2, "should suggest the strains with the prefix")
+	for _, sg := range sgs {
+		assert.Contains([]string{ids["AX4"], ids["AX2"]}, sg.ID)
+	}
+	sgs, err = repo.SuggestStocks(context.Background(), "carA", 1)
+	assert.NoError(err, "expect no error from completing with limit")
+	assert.Len(sgs, 1, "should match the provided limit")
+	sgs, err = repo.SuggestStocks(context.Background(), "cara-/carb-", 10)
+	assert.NoError(err, "expect no error from completing full label")
+	assert.NotEmpty(sgs, "should suggest the strain with the label")
+	assert.Equal(ids["carA-/carB-"], sgs[0].ID, "should rank the exact label first")
+	sgs, err = repo.SuggestStocks(context.Background(), "crA-/carB-", 10)
+	assert.NoError(err, "expect no error from completing misspelled label")
+	assert.NotEmpty(sgs, "should suggest strains for a misspelled label")
+	assert.Equal(ids["carA-/carB-"], sgs[0].ID, "should rank the closest label first")
+	sgs, err = repo.SuggestStocks(context.Background(), "gamma", 10)
+	assert.NoError(err, "expect no error from completing names")
+	assert.Len(sgs, 5, "should suggest the strains by their names")
+	sgs, err = repo.SuggestStocks(context.Background(), "zzzz", 10)
+	assert.NoError(err, "expect no error from text without any match")
+	assert.Empty(sgs, "should not suggest any stock")
+}
@@ -0,0 +1,86 @@
+package arangodb
+
+import (
+	driver "github.com/arangodb/go-driver"
+	"github.com/cockroachdb/errors"
+	"github.com/dictyBase/modware-stock/internal/model"
+	"github.com/dictyBase/modware-stock/internal/repository"
+)
+
+// revisionBindParams verifies the expected combined revision of a stock
+// and provides the bind parameters that make the update fail if any of the
+// stock or stockprop documents changes in the meantime. An empty revision
+// turns off the check.
+func (ar *arangorepository) revisionBindParams(
+	tx *dbTx,
+	id, propKey, rev string,
+) (map[string]interface{}, error) {
+	bindVars := map[string]interface{}{
+		"rev":         "",
+		"prop_rev":    "",
+		"ignore_revs": true,
+	}
+	if len(rev) == 0 {
+		return bindVars, nil
+	}
+	if err := ar.checkRevision(tx, id, propKey, rev); err != nil {
+		return bindVars, err
+	}
+	stockRev, propRev, _ := model.SplitRevision(rev)
+	bindVars["rev"] = stockRev
+	bindVars["prop_rev"] = propRev
+	bindVars["ignore_revs"] = false
+	return bindVars, nil
+}
+
+// checkRevision compares the expected combined revision with the current
+// revisions of the stock and its stockprop document
+func (ar *arangorepository) checkRevision(
+	tx *dbTx,
+	id, propKey, rev string,
+) error {
+	stockRev, propRev, ok := model.SplitRevision(rev)
+	if !ok {
+		return errors.Wrapf(
+			repository.ErrRevisionMismatch,
+			"malformed revision %s of stock %s",
+			rev, id,
+		)
+	}
+	sm, err := ar.stockc.stock.ReadDocument(
+		tx.ctx, id, &model.StockDoc{},
+	)
+	if err != nil {
+		return errors.Errorf("error in reading stock %s %s", id, err)
+	}
+	pm, err := ar.stockc.stockProp.ReadDocument(
+		tx.ctx, propKey, &map[string]interface{}{},
+	)
+	if err != nil {
+		return errors.Errorf("error in reading properties of stock %s %s", id, err)
+	}
+	if sm.Rev != stockRev || pm.Rev != propRev {
+		return errors.Wrapf(
+			repository.ErrRevisionMismatch,
+			"stock %s is at revision %s",
+			id, (&model.StockDoc{
+				DocumentMeta: sm,
+				PropRev:      pm.Rev,
+			}).Revision(),
+		)
+	}
+	return nil
+}
+
+// updateError reports a failed update of a stock. A conflict on the
+// expected revision is reported as a revision mismatch.
+func updateError(id, rev string, err error) error {
+	if len(rev) > 0 && driver.IsConflict(err) {
+		return errors.Wrapf(
+			repository.ErrRevisionMismatch,
+			"stock %s was modified concurrently",
+			id,
+		)
+	}
+	return errors.Errorf("error in editing stock %s %s", id, err)
+}
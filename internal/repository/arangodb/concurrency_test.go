@@ -0,0 +1,76 @@
+package arangodb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dictyBase/go-genproto/dictybaseapis/stock"
+	"github.com/dictyBase/modware-stock/internal/repository"
+)
+
+func TestEditStrainWithRevision(t *testing.T) {
+	t.Parallel()
+	assert, repo := setUp(t)
+	defer tearDown(repo)
+	ns := newUpdatableTestStrain("todd@gagg.com", General)
+	m, err := repo.AddStrain(context.Background(), ns)
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	g, err := repo.GetStrain(context.Background(), m.StockID)
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	rev := g.Revision()
+	assert.NotEmpty(g.Rev, "should have revision of stock document")
+	assert.NotEmpty(g.PropRev, "should have revision of stockprop document")
+	us := strainUpdateInstance(ns, m)
+	um, err := repo.EditStrain(context.Background(), us, rev)
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	assert.NotEqual(um.Revision(), rev, "should change the revision")
+	_, err = repo.EditStrain(context.Background(), us, rev)
+	assert.ErrorIs(
+		err,
+		repository.ErrRevisionMismatch,
+		"should not update with a stale revision",
+	)
+	_, err = repo.EditStrain(context.Background(), us, "malformed")
+	assert.ErrorIs(
+		err,
+		repository.ErrRevisionMismatch,
+		"should not update with a malformed revision",
+	)
+	g2, err := repo.GetStrain(context.Background(), m.StockID)
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	assert.Equal(g2.Revision(), um.Revision(), "should match updated revision")
+	_, err = repo.EditStrain(context.Background(), us, g2.Revision())
+	assert.NoErrorf(err, "expect no error, received %s", err)
+}
+
+func TestEditPlasmidWithRevision(t *testing.T) {
+	t.Parallel()
+	assert, repo := setUp(t)
+	defer tearDown(repo)
+	m, err := repo.AddPlasmid(context.Background(), newUpdatableTestPlasmid("art@vandelay.org"))
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	g, err := repo.GetPlasmid(context.Background(), m.StockID)
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	us := &stock.PlasmidUpdate{
+		Data: &stock.PlasmidUpdate_Data{
+			Type: "plasmid",
+			Id:   m.StockID,
+			Attributes: &stock.PlasmidUpdateAttributes{
+				UpdatedBy: "varnes@seinfeld.org",
+				Summary:   "updated plasmid",
+			},
+		},
+	}
+	_, err = repo.EditPlasmid(context.Background(), us, g.Revision())
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	us.Data.Attributes.Summary = "stale plasmid"
+	_, err = repo.EditPlasmid(context.Background(), us, g.Revision())
+	assert.ErrorIs(
+		err,
+		repository.ErrRevisionMismatch,
+		"should not update with a stale revision",
+	)
+	g2, err := repo.GetPlasmid(context.Background(), m.StockID)
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	assert.Equal(g2.Summary, "updated plasmid", "should keep the first update")
+}
@@ -0,0 +1,203 @@
+package arangodb
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"github.com/dictyBase/modware-stock/internal/model"
+	"github.com/dictyBase/modware-stock/internal/repository"
+)
+
+var (
+	defaultSort = []model.SortKey{{Field: "created_at", Descending: true}}
+	// traversedVars are bound by the strain queries that traverse the
+	// ontology terms and the parents
+	traversedVars = map[string]bool{"cv": true, "cvterm": true, "parents": true}
+)
+
+// pageQuery is the part of a list query that a page is filtered, sorted
+// and continued by
+type pageQuery struct {
+	// fields are the fields of the listed stock type
+	fields map[string]stockField
+	// vars are the variables bound by the query, the filter and sort
+	// fields could only refer to them
+	vars map[string]bool
+	// key is the unique key of the stock, it breaks the ties in sorting
+	key string
+	// numericKey compares the keys as numbers, as of the keys generated
+	// in the order of creation
+	numericKey bool
+}
+
+var (
+	strainPage = pageQuery{
+		fields: strainFields,
+		vars:   map[string]bool{"s": true, "stock_prop": true},
+		key:    "s._key",
+	}
+	strainTermPage = pageQuery{
+		fields: strainFields,
+		vars: map[string]bool{
+			"s": true, "stock_prop": true, "cv": true, "cvterm": true,
+			"parents": true,
+		},
+		key: "s._key",
+	}
+	plasmidPage = pageQuery{
+		fields: plasmidFields,
+		vars:   map[string]bool{"s": true, "stock_prop": true},
+		key:    "s._key",
+	}
+	asOfPage = pageQuery{
+		fields: asOfStrainFields,
+		vars:   map[string]bool{"s": true, "stock_prop": true, "parents": true},
+		key:    "id",
+	}
+)
+
+// unboundFields gives the fields that refer to a variable the query does
+// not bind
+func (pq pageQuery) unboundFields() []string {
+	var names []string
+	for _, name := range fieldNames(pq.fields) {
+		if !pq.vars[pq.fields[name].variable()] {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+func sortKeys(keys []model.SortKey) []model.SortKey {
+	if len(keys) == 0 {
+		return defaultSort
+	}
+	return keys
+}
+
+// sortField gives the field of the stock type that a sort key maps to
+func (pq pageQuery) sortField(key model.SortKey) (stockField, error) {
+	field, ok := pq.fields[key.Field]
+	if !ok {
+		return field, errors.Wrapf(
+			repository.ErrInvalidSort, "unsupported sort field %s", key.Field,
+		)
+	}
+	return field, nil
+}
+
+// sortsByTraversal tells whether any of the sort keys refers to the
+// ontology term or the parents of a strain
+func sortsByTraversal(keys []model.SortKey) bool {
+	for _, k := range keys {
+		if f, ok := strainFields[k.Field]; ok && traversedVars[f.variable()] {
+			return true
+		}
+	}
+	return false
+}
+
+func (pq pageQuery) sortExprs(keys []model.SortKey) ([]string, error) {
+	exprs := make([]string, 0, len(keys))
+	for _, k := range keys {
+		field, err := pq.sortField(k)
+		if err != nil {
+			return exprs, err
+		}
+		if !pq.vars[field.variable()] {
+			return exprs, errors.Wrapf(
+				repository.ErrInvalidSort,
+				"stocks could not be sorted by %s", k.Field,
+			)
+		}
+		expr := field.expr
+		// dates are sorted by their timestamp, so that the differences
+		// in their formatting do not matter
+		if field.kind == dateField {
+			expr = fmt.Sprintf("DATE_TIMESTAMP(%s)", expr)
+		}
+		exprs = append(exprs, expr)
+	}
+	return exprs, nil
+}
+
+// clause gives the sort values of a stock along with the FILTER that
+// starts a list after the cursor and the SORT of the list. A backward
+// cursor reverses the sort order, so that the nearest stock to it comes
+// first. The values of the cursor are added to bindVars.
+func (pq pageQuery) clause(
+	p *model.ListParams,
+	bindVars map[string]interface{},
+) (string, error) {
+	keys := sortKeys(p.Sort)
+	exprs, err := pq.sortExprs(keys)
+	if err != nil {
+		return "", err
+	}
+	backward := p.Cursor != nil && p.Cursor.Backward
+	values := make([]string, len(keys))
+	cmps := make([]string, len(keys))
+	sorts := make([]string, 0, len(keys)+1)
+	dir := ""
+	for i, k := range keys {
+		values[i] = fmt.Sprintf("sort_values[%d]", i)
+		cmps[i], dir = "<", "DESC"
+		if k.Descending == backward {
+			cmps[i], dir = ">", "ASC"
+		}
+		sorts = append(sorts, values[i]+" "+dir)
+	}
+	// the key follows the direction of the last sort key
+	sorts = append(sorts, pq.key+" "+dir)
+	var clause strings.Builder
+	fmt.Fprintf(&clause, "LET sort_values = [%s]\n", strings.Join(exprs, ", "))
+	if p.Cursor != nil {
+		cond, err := pq.cursorCondition(p.Cursor, values, cmps, bindVars)
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(&clause, "FILTER %s\n", cond)
+	}
+	fmt.Fprintf(&clause, "SORT %s", strings.Join(sorts, ", "))
+	return clause.String(), nil
+}
+
+// cursorCondition gives the condition for the stocks that come after the
+// cursor, comparing the sort values one after another and finally the key
+func (pq pageQuery) cursorCondition(
+	c *model.StockCursor,
+	values, cmps []string,
+	bindVars map[string]interface{},
+) (string, error) {
+	if len(c.Values) != len(values) {
+		return "", errors.Wrap(
+			repository.ErrInvalidSort, "cursor does not match the sort order",
+		)
+	}
+	conds := make([]string, 0, len(values)+1)
+	var equals []string
+	for i, v := range values {
+		param := fmt.Sprintf("@cursor%d", i)
+		bindVars[param[1:]] = c.Values[i]
+		cond := append(
+			append([]string{}, equals...),
+			fmt.Sprintf("%s %s %s", v, cmps[i], param),
+		)
+		conds = append(conds, "("+strings.Join(cond, " AND ")+")")
+		equals = append(equals, fmt.Sprintf("%s == %s", v, param))
+	}
+	bindVars["cursor_key"] = nil
+	if len(c.Key) > 0 {
+		bindVars["cursor_key"] = c.Key
+	}
+	key, param := pq.key, "@cursor_key"
+	if pq.numericKey {
+		key, param = "TO_NUMBER("+key+")", "TO_NUMBER("+param+")"
+	}
+	conds = append(conds, fmt.Sprintf(
+		"(%s AND (@cursor_key == null OR %s %s %s))",
+		strings.Join(equals, " AND "), key, cmps[len(cmps)-1], param,
+	))
+	return strings.Join(conds, "\n\tOR "), nil
+}
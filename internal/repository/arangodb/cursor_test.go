@@ -0,0 +1,237 @@
+package arangodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/dictyBase/aphgrpc"
+	"github.com/dictyBase/go-genproto/dictybaseapis/stock"
+	"github.com/dictyBase/modware-stock/internal/model"
+	"github.com/dictyBase/modware-stock/internal/repository"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestExistingStrain(tm time.Time, label string) *stock.ExistingStrain {
+	return &stock.ExistingStrain{
+		Data: &stock.ExistingStrain_Data{
+			Type: "strain",
+			Attributes: &stock.ExistingStrainAttributes{
+				CreatedAt:           aphgrpc.TimestampProto(tm),
+				UpdatedAt:           aphgrpc.TimestampProto(tm),
+				CreatedBy:           "kramer@costanza.com",
+				UpdatedBy:           "kramer@costanza.com",
+				Depositor:           "kramer@costanza.com",
+				Summary:             "Bulk loaded strain",
+				Label:               label,
+				Species:             "Dictyostelium discoideum",
+				DictyStrainProperty: "general strain",
+			},
+		},
+	}
+}
+
+func TestListStrainsWithSharedCreatedAt(t *testing.T) {
+	t.Parallel()
+	assert, repo := setUp(t)
+	defer tearDown(repo)
+	tm, _ := time.Parse("2006-01-02 15:04:05", "2012-06-14 09:12:33")
+	for i := 1; i <= 7; i++ {
+		_, err := repo.LoadStrain(
+			context.Background(),
+			fmt.Sprintf("DBS09%05d", i),
+			newTestExistingStrain(tm, "bulk"),
+		)
+		assert.NoErrorf(err, "expect no error, received %s", err)
+	}
+	seen := make(map[string]bool)
+	var keys []string
+	var cursor *model.StockCursor
+	for {
+		ls, err := repo.ListStrains(
+			context.Background(),
+			&model.ListParams{Cursor: cursor, Limit: 3},
+		)
+		assert.NoErrorf(err, "expect no error, received %s", err)
+		page := ls
+		if len(ls) > 3 {
+			page = ls[:3]
+		}
+		for _, m := range page {
+			assert.Falsef(seen[m.Key], "should not repeat strain %s", m.Key)
+			seen[m.Key] = true
+			keys = append(keys, m.Key)
+		}
+		if len(ls) <= 3 {
+			break
+		}
+		last := page[len(page)-1]
+		cursor = &model.StockCursor{Values: last.SortValues, Key: last.Key}
+	}
+	assert.Len(keys, 7, "should list all strains with shared creation time")
+	for i := 1; i < len(keys); i++ {
+		assert.Greater(keys[i-1], keys[i], "should sort strains by key")
+	}
+	ls, err := repo.ListStrains(
+		context.Background(),
+		&model.ListParams{
+			Cursor: &model.StockCursor{Values: []interface{}{tm.UnixMilli()}},
+			Limit:  10,
+		},
+	)
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	assert.Len(ls, 7, "should include all strains created at cursor without key")
+}
+
+func TestListStrainsBackward(t *testing.T) {
+	t.Parallel()
+	assert, repo := setUp(t)
+	defer tearDown(repo)
+	tm, _ := time.Parse("2006-01-02 15:04:05", "2012-06-14 09:12:33")
+	for i := 1; i <= 5; i++ {
+		_, err := repo.LoadStrain(
+			context.Background(),
+			fmt.Sprintf("DBS09%05d", i),
+			newTestExistingStrain(tm, "bulk"),
+		)
+		assert.NoErrorf(err, "expect no error, received %s", err)
+	}
+	all, err := repo.ListStrains(
+		context.Background(),
+		&model.ListParams{Limit: 10},
+	)
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	assert.Len(all, 5, "should list all strains")
+	last := all[len(all)-1]
+	ls, err := repo.ListStrains(
+		context.Background(),
+		&model.ListParams{
+			Cursor: &model.StockCursor{
+				Values:   last.SortValues,
+				Key:      last.Key,
+				Backward: true,
+			},
+			Limit: 2,
+		},
+	)
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	assert.Len(ls, 3, "should match the provided limit number + 1")
+	for i, m := range ls {
+		assert.Equal(
+			all[len(all)-2-i].Key,
+			m.Key,
+			"should list the nearest strain before the cursor first",
+		)
+	}
+}
+
+func TestListStrainsSorted(t *testing.T) {
+	t.Parallel()
+	assert, repo := setUp(t)
+	defer tearDown(repo)
+	tm, _ := time.Parse("2006-01-02 15:04:05", "2012-06-14 09:12:33")
+	labels := []string{"carA-", "ax4", "carB-", "ax4", "ax2", "dh1"}
+	for i, l := range labels {
+		_, err := repo.LoadStrain(
+			context.Background(),
+			fmt.Sprintf("DBS09%05d", i+1),
+			newTestExistingStrain(tm.Add(time.Duration(i)*time.Hour), l),
+		)
+		assert.NoErrorf(err, "expect no error, received %s", err)
+	}
+	sort := []model.SortKey{
+		{Field: "label"},
+		{Field: "created_at", Descending: true},
+	}
+	var got []string
+	var cursor *model.StockCursor
+	for {
+		ls, err := repo.ListStrains(
+			context.Background(),
+			&model.ListParams{Cursor: cursor, Limit: 4, Sort: sort},
+		)
+		assert.NoErrorf(err, "expect no error, received %s", err)
+		page := ls
+		if len(ls) > 4 {
+			page = ls[:4]
+		}
+		for _, m := range page {
+			got = append(got, m.Key)
+		}
+		if len(ls) <= 4 {
+			break
+		}
+		last := page[len(page)-1]
+		cursor = &model.StockCursor{Values: last.SortValues, Key: last.Key}
+	}
+	assert.Equal(
+		[]string{
+			"DBS0900005", "DBS0900004", "DBS0900002",
+			"DBS0900001", "DBS0900003", "DBS0900006",
+		},
+		got,
+		"should sort by label and then by the most recent first",
+	)
+	ls, err := repo.ListStrains(
+		context.Background(),
+		&model.ListParams{
+			Limit: 10,
+			Sort:  []model.SortKey{{Field: "tag"}, {Field: "depositor"}},
+		},
+	)
+	assert.NoErrorf(err, "expect no error from sorting by term, received %s", err)
+	assert.Len(ls, 6, "should list all strains")
+	for _, sk := range [][]model.SortKey{
+		{{Field: "borat"}},
+		{{Field: "parent"}},
+	} {
+		_, err := repo.ListStrains(
+			context.Background(),
+			&model.ListParams{Limit: 10, Sort: sk},
+		)
+		assert.Truef(
+			errors.Is(err, repository.ErrInvalidSort),
+			"expect invalid sort error from sorting by %s", sk[0].Field,
+		)
+	}
+	_, err = repo.ListPlasmids(
+		context.Background(),
+		&model.ListParams{Limit: 10, Sort: []model.SortKey{{Field: "tag"}}},
+	)
+	assert.True(
+		errors.Is(err, repository.ErrInvalidSort),
+		"expect invalid sort error from sorting plasmids by term",
+	)
+	_, err = repo.ListStrains(
+		context.Background(),
+		&model.ListParams{
+			Limit:  10,
+			Sort:   sort,
+			Cursor: createdCursor(tm),
+		},
+	)
+	assert.True(
+		errors.Is(err, repository.ErrInvalidSort),
+		"expect invalid sort error from cursor of another sort order",
+	)
+}
+
+func TestCursorConditionNumericKey(t *testing.T) {
+	t.Parallel()
+	assert := require.New(t)
+	bindVars := make(map[string]interface{})
+	cond, err := revisionPage.cursorCondition(
+		&model.StockCursor{Values: []interface{}{int64(1339665153000)}, Key: "9"},
+		[]string{"sort_values[0]"},
+		[]string{"<"},
+		bindVars,
+	)
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	assert.Contains(
+		cond, "TO_NUMBER(r._key) < TO_NUMBER(@cursor_key)",
+		"should compare the revision keys as numbers",
+	)
+	assert.Equal("9", bindVars["cursor_key"], "should bind the key of the cursor")
+}
@@ -1,8 +1,11 @@
 package arangodb
 
 import (
+	"time"
+
 	driver "github.com/arangodb/go-driver"
 	"github.com/cockroachdb/errors"
+	"github.com/dictyBase/modware-stock/internal/model"
 )
 
 // CollectionParams are the arangodb collections required for storing stocks
@@ -13,6 +16,8 @@ type CollectionParams struct {
 	StockProp string `validate:"required"`
 	// StockKeyGenerator is the collection for generating unique stock IDs
 	StockKeyGenerator string `validate:"required"`
+	// StockRevision is the collection for storing the change history of stocks
+	StockRevision string `validate:"required"`
 	// StockType is the edge collection for connecting stocks to their types
 	StockType string `validate:"required"`
 	// ParentStrain is the edge collection for connecting strains to their parents
@@ -32,17 +37,28 @@ type CollectionParams struct {
 	StockOntoGraph string `validate:"required"`
 	// StrainOntology is the name ontology for storing strain group
 	StrainOntology string `validate:"required"`
+	// SearchView is the arangosearch view for the full text search of
+	// stocks
+	SearchView string `validate:"required"`
+	// LockTimeout is the time a write transaction waits for acquiring its
+	// collection locks, defaults to 30 seconds
+	LockTimeout time.Duration
 }
 
 type stockc struct {
 	stock, stockProp, stockKey         driver.Collection
+	stockRevision                      driver.Collection
 	stockType, parentStrain, stockTerm driver.Collection
 	stockPropType, strain2Parent       driver.Graph
 	stockOnto                          driver.Graph
 }
 
 type persistStrainParams struct {
-	parent, dictyStrainProp    string
+	// id is the stock id of a loaded strain, a new strain is yet to get one
+	id                         string
+	action, actor              string
+	dictyStrainProp            string
+	parents                    []*model.StrainParent
 	statement, parentStatement string
 	bindVars                   map[string]interface{}
 }
@@ -54,7 +70,10 @@ func createDbStruct(ar *arangorepository, collP *CollectionParams) error {
 	if err := graphAndEdgeCollections(ar, collP); err != nil {
 		return err
 	}
-	return createIndex(ar)
+	if err := createIndex(ar); err != nil {
+		return err
+	}
+	return createSearchView(ar, collP)
 }
 
 func docCollections(ar *arangorepository, collP *CollectionParams) error {
@@ -84,10 +103,18 @@ func docCollections(ar *arangorepository, collP *CollectionParams) error {
 	if err != nil {
 		return errors.Errorf("error in creating collection %s %s", collP.StockKeyGenerator, err)
 	}
+	revc, err := db.FindOrCreateCollection(
+		collP.StockRevision,
+		&driver.CreateCollectionOptions{},
+	)
+	if err != nil {
+		return errors.Errorf("error in creating collection %s %s", collP.StockRevision, err)
+	}
 	ar.stockc = &stockc{
-		stockProp: spropc,
-		stockKey:  stockkeyc,
-		stock:     stkc,
+		stockProp:     spropc,
+		stockKey:      stockkeyc,
+		stock:         stkc,
+		stockRevision: revc,
 	}
 	return nil
 }
@@ -181,5 +208,47 @@ func createIndex(ar *arangorepository) error {
 	if err != nil {
 		return errors.Errorf("error in creating index %s", err)
 	}
+	_, _, err = ar.database.EnsurePersistentIndex(
+		ar.stockc.stockRevision.Name(),
+		[]string{"stock_id", "created_at"},
+		&driver.EnsurePersistentIndexOptions{
+			InBackground: true,
+			Name:         "stock_revision_idx",
+		})
+	if err != nil {
+		return errors.Errorf("error in creating index %s", err)
+	}
+	// the stocks that changed since a watermark are found by these
+	_, _, err = ar.database.EnsurePersistentIndex(
+		ar.stockc.stock.Name(),
+		[]string{"updated_at"},
+		&driver.EnsurePersistentIndexOptions{
+			InBackground: true,
+			Name:         "stock_updated_at_idx",
+		})
+	if err != nil {
+		return errors.Errorf("error in creating index %s", err)
+	}
+	_, _, err = ar.database.EnsurePersistentIndex(
+		ar.stockc.stockRevision.Name(),
+		[]string{"action", "created_at"},
+		&driver.EnsurePersistentIndexOptions{
+			InBackground: true,
+			Name:         "stock_revision_action_idx",
+		})
+	if err != nil {
+		return errors.Errorf("error in creating index %s", err)
+	}
+	// the stocks as of a time are read from the revisions until then
+	_, _, err = ar.database.EnsurePersistentIndex(
+		ar.stockc.stockRevision.Name(),
+		[]string{"created_at"},
+		&driver.EnsurePersistentIndexOptions{
+			InBackground: true,
+			Name:         "stock_revision_created_at_idx",
+		})
+	if err != nil {
+		return errors.Errorf("error in creating index %s", err)
+	}
 	return nil
 }
@@ -0,0 +1,73 @@
+package arangodb
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"github.com/dictyBase/modware-stock/internal/model"
+	"github.com/dictyBase/modware-stock/internal/repository"
+	"github.com/dictyBase/modware-stock/internal/repository/arangodb/statement"
+)
+
+// strainFacets are the fields of strains that could be counted by their
+// values, in the order they are given by default
+var strainFacets = []string{
+	"species", "depositor", "dicty_strain_property", "has_plasmid",
+}
+
+// StrainFacets counts the strains that match the filter by the distinct
+// values of every facet field, all the facets are counted without any
+// field
+func (ar *arangorepository) StrainFacets(
+	ctx context.Context,
+	filter string,
+	fields []string,
+) ([]*model.Facet, error) {
+	if len(fields) == 0 {
+		fields = strainFacets
+	}
+	for _, f := range fields {
+		if !hasFacet(f) {
+			return []*model.Facet{}, errors.Wrapf(
+				repository.ErrInvalidFacet,
+				"unsupported facet field %s, valid facets are %s",
+				f, strings.Join(strainFacets, ", "),
+			)
+		}
+	}
+	clause, bindVars, err := filterClause(filter, strainTermPage)
+	if err != nil {
+		return []*model.Facet{}, err
+	}
+	bindVars["@stock_collection"] = ar.stockc.stock.Name()
+	bindVars["@cv_collection"] = ar.ontoc.Cv.Name()
+	bindVars["stock_prop_graph"] = ar.stockc.stockPropType.Name()
+	bindVars["stock_cvterm_graph"] = ar.stockc.stockOnto.Name()
+	bindVars["ontology"] = ar.strainOnto
+	bindVars["facets"] = fields
+	stmt := statement.StrainFacetSource + statement.StrainFacetCounts
+	// the ontology terms are only traversed for filtering
+	if len(clause) > 0 {
+		delete(bindVars, "@stock_collection")
+		bindVars["@cvterm_collection"] = ar.ontoc.Term.Name()
+		bindVars["parent_graph"] = ar.stockc.strain2Parent.Name()
+		stmt = fmt.Sprintf(statement.StrainFacetSourceFilter, clause) +
+			statement.StrainFacetCounts
+	}
+	facets, err := searchRows[model.Facet](ar.directTx(ctx), stmt, bindVars)
+	if err != nil {
+		return facets, errors.Errorf("error in counting strain facets %s", err)
+	}
+	return facets, nil
+}
+
+func hasFacet(field string) bool {
+	for _, f := range strainFacets {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,97 @@
+package arangodb
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/dictyBase/modware-stock/internal/model"
+	"github.com/dictyBase/modware-stock/internal/repository"
+)
+
+func facetCounts(facets []*model.Facet, field string) map[string]int64 {
+	counts := make(map[string]int64)
+	for _, f := range facets {
+		if f.Field != field {
+			continue
+		}
+		for _, c := range f.Counts {
+			counts[c.Value] = c.Count
+		}
+	}
+	return counts
+}
+
+func TestStrainFacets(t *testing.T) {
+	t.Parallel()
+	assert, repo := setUp(t)
+	defer tearDown(repo)
+	err := createTestStrains(6, General, repo)
+	assert.NoError(err, "expect no error from creating strains")
+	for i := 0; i < 3; i++ {
+		ns := newTestStrain("kramer@costanza.com", Bacterial)
+		ns.Data.Attributes.Species = "Dictyostelium purpureum"
+		ns.Data.Attributes.Depositor = "kramer@costanza.com"
+		ns.Data.Attributes.Plasmid = ""
+		_, err := repo.AddStrain(context.Background(), ns)
+		assert.NoError(err, "expect no error from adding strain")
+	}
+	_, err = repo.AddPlasmid(context.Background(), newTestPlasmid("kramer@costanza.com"))
+	assert.NoError(err, "expect no error from adding plasmid")
+	facets, err := repo.StrainFacets(context.Background(), "", nil)
+	assert.NoError(err, "expect no error from counting all facets")
+	assert.Len(facets, 4, "should count all the facets")
+	assert.Equal("species", facets[0].Field, "should keep the default order")
+	assert.Equal(
+		map[string]int64{
+			"Dictyostelium discoideum": 6,
+			"Dictyostelium purpureum":  3,
+		},
+		facetCounts(facets, "species"),
+	)
+	assert.Equal(
+		map[string]int64{"george@costanza.com": 6, "kramer@costanza.com": 3},
+		facetCounts(facets, "depositor"),
+	)
+	assert.Equal(
+		map[string]int64{"general strain": 6, "bacterial strain": 3},
+		facetCounts(facets, "dicty_strain_property"),
+	)
+	assert.Equal(
+		map[string]int64{"true": 6, "false": 3},
+		facetCounts(facets, "has_plasmid"),
+	)
+	assert.Equal(int64(6), facets[0].Counts[0].Count, "should give the most common value first")
+	facets, err = repo.StrainFacets(
+		context.Background(),
+		"ontology==dicty_strain_property;tag==bacterial strain",
+		[]string{"has_plasmid", "species"},
+	)
+	assert.NoError(err, "expect no error from counting filtered facets")
+	assert.Len(facets, 2, "should only count the requested facets")
+	assert.Equal("has_plasmid", facets[0].Field, "should keep the requested order")
+	assert.Equal(map[string]int64{"false": 3}, facetCounts(facets, "has_plasmid"))
+	assert.Equal(
+		map[string]int64{"Dictyostelium purpureum": 3},
+		facetCounts(facets, "species"),
+	)
+	facets, err = repo.StrainFacets(
+		context.Background(), "depositor==george@costanza.com", []string{"depositor"},
+	)
+	assert.NoError(err, "expect no error from filter without ontology")
+	assert.Equal(
+		map[string]int64{"george@costanza.com": 6},
+		facetCounts(facets, "depositor"),
+		"should count a strain only once",
+	)
+	_, err = repo.StrainFacets(context.Background(), "", []string{"summary"})
+	assert.True(
+		errors.Is(err, repository.ErrInvalidFacet),
+		"expect invalid facet error from unsupported facet",
+	)
+	_, err = repo.StrainFacets(context.Background(), "borat==funny", nil)
+	assert.True(
+		errors.Is(err, repository.ErrInvalidFilter),
+		"expect invalid filter error from unsupported filter field",
+	)
+}
@@ -1,19 +1,149 @@
 package arangodb
 
-// FMap maps filters to database fields
-var FMap = map[string]string{
-	"created_at":   "s.created_at",
-	"updated_at":   "s.updated_at",
-	"depositor":    "s.depositor",
-	"summary":      "s.summary",
-	"id":           "s.stock_id",
-	"gene":         "s.genes",
-	"plasmid":      "stock_prop.plasmid",
-	"species":      "stock_prop.species",
-	"name":         "stock_prop.names",
-	"label":        "stock_prop.label",
-	"ontology":     "cv.metadata.namespace",
-	"tag":          "cvterm.label",
-	"parent":       "parent",
-	"plasmid_name": "name",
+import (
+	"sort"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"github.com/dictyBase/modware-stock/internal/model"
+)
+
+// fieldKind is the type of the values of a stock field
+type fieldKind string
+
+const (
+	stringField fieldKind = "string"
+	// dateField values are compared as dates, they are filtered by the
+	// $ prefixed operators
+	dateField fieldKind = "date"
+	// arrayField values are lists of strings, they are filtered by the
+	// @ prefixed operators or by ANY and ALL
+	arrayField fieldKind = "array"
+)
+
+// kindOperators are the filter operators of every kind of field. The ANY
+// and ALL operators apply any of the quantOps to the elements of an
+// array, as in ANY(gene)==DDB_G0348394.
+var kindOperators = map[fieldKind][]string{
+	stringField: {
+		"==", "!=", "===", "!==", "=~", "!~", ">", "<", ">=", "<=",
+		"IN", "NOT IN",
+	},
+	dateField: {"$==", "$>", "$<", "$>=", "$<=", "BETWEEN"},
+	arrayField: {
+		"@==", "@!=", "@=~", "@!~",
+		"ANY ==", "ANY !=", "ANY =~", "ANY !~", "ANY IN", "ANY NOT IN",
+		"ALL ==", "ALL !=", "ALL =~", "ALL !~", "ALL IN", "ALL NOT IN",
+	},
+}
+
+// stockField is a field that a list of stocks could be filtered and
+// sorted by
+type stockField struct {
+	// expr is the database field, qualified by the query variable that
+	// holds it
+	expr string
+	kind fieldKind
+}
+
+// variable gives the query variable of the field
+func (f stockField) variable() string {
+	if i := strings.IndexAny(f.expr, ".["); i >= 0 {
+		return f.expr[:i]
+	}
+	return f.expr
+}
+
+var commonFields = map[string]stockField{
+	"created_at":  {expr: "s.created_at", kind: dateField},
+	"updated_at":  {expr: "s.updated_at", kind: dateField},
+	"depositor":   {expr: "s.depositor", kind: stringField},
+	"summary":     {expr: "s.summary", kind: stringField},
+	"id":          {expr: "s.stock_id", kind: stringField},
+	"gene":        {expr: "s.genes", kind: arrayField},
+	"publication": {expr: "s.publications", kind: arrayField},
+}
+
+// strainFields are the fields of strain lists, the ontology and the
+// parent fields are only bound by the queries that traverse the strain
+// terms and parents
+var strainFields = withCommonFields(map[string]stockField{
+	"plasmid":  {expr: "stock_prop.plasmid", kind: stringField},
+	"species":  {expr: "stock_prop.species", kind: stringField},
+	"name":     {expr: "stock_prop.names", kind: arrayField},
+	"label":    {expr: "stock_prop.label", kind: stringField},
+	"ontology": {expr: "cv.metadata.namespace", kind: stringField},
+	"tag":      {expr: "cvterm.label", kind: stringField},
+	"parent":   {expr: "parents[*].id", kind: arrayField},
+	"parent_relationship": {
+		expr: "parents[*].relationship",
+		kind: arrayField,
+	},
+})
+
+// asOfStrainFields are the fields of strain lists as of a time, the
+// strains are read from their revisions, where only the strain property
+// is kept from the ontology terms
+var asOfStrainFields = withCommonFields(map[string]stockField{
+	"plasmid": {expr: "stock_prop.plasmid", kind: stringField},
+	"species": {expr: "stock_prop.species", kind: stringField},
+	"name":    {expr: "stock_prop.names", kind: arrayField},
+	"label":   {expr: "stock_prop.label", kind: stringField},
+	"tag": {
+		expr: "stock_prop.dicty_strain_property",
+		kind: stringField,
+	},
+	"parent": {expr: "parents[*].id", kind: arrayField},
+	"parent_relationship": {
+		expr: "parents[*].relationship",
+		kind: arrayField,
+	},
+})
+
+// plasmidFields are the fields of plasmid lists
+var plasmidFields = withCommonFields(map[string]stockField{
+	"plasmid_name": {expr: "stock_prop.name", kind: stringField},
+})
+
+func withCommonFields(fields map[string]stockField) map[string]stockField {
+	for name, f := range commonFields {
+		fields[name] = f
+	}
+	return fields
+}
+
+// fieldNames gives the sorted names of the fields
+func fieldNames(fields map[string]stockField) []string {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ListFilterableFields gives the fields that a list of the stock type
+// could be filtered by, along with their type and operators
+func (ar *arangorepository) ListFilterableFields(
+	stockType string,
+) ([]*model.FilterableField, error) {
+	var fields map[string]stockField
+	switch stockType {
+	case "strain":
+		fields = strainFields
+	case "plasmid":
+		fields = plasmidFields
+	default:
+		return []*model.FilterableField{},
+			errors.Errorf("unsupported stock type %s", stockType)
+	}
+	ffs := make([]*model.FilterableField, 0, len(fields))
+	for _, name := range fieldNames(fields) {
+		ffs = append(ffs, &model.FilterableField{
+			Name:      name,
+			Type:      string(fields[name].kind),
+			Operators: kindOperators[fields[name].kind],
+		})
+	}
+	return ffs, nil
 }
@@ -0,0 +1,94 @@
+package arangodb
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/dictyBase/modware-stock/internal/repository"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFieldMaps(t *testing.T) {
+	t.Parallel()
+	assert := require.New(t)
+	for name, pq := range map[string]pageQuery{
+		"strain":  strainTermPage,
+		"plasmid": plasmidPage,
+		"as of":   asOfPage,
+	} {
+		assert.Emptyf(
+			pq.unboundFields(),
+			"should bind all the fields of the %s list query", name,
+		)
+	}
+	assert.Equal(
+		[]string{"ontology", "parent", "parent_relationship", "tag"},
+		strainPage.unboundFields(),
+		"should only leave the traversed fields unbound without traversals",
+	)
+	for _, fstr := range []string{"label==AX4", "tag==REMI-seq", "parent==DBS0236123"} {
+		_, _, err := filterClause(fstr, plasmidPage)
+		assert.Truef(
+			errors.Is(err, repository.ErrInvalidFilter),
+			"expect invalid filter error from plasmid filter %s", fstr,
+		)
+		assert.Contains(
+			err.Error(),
+			"valid fields are created_at, depositor, gene, id, plasmid_name, publication, summary, updated_at",
+			"should name the valid plasmid fields",
+		)
+	}
+	_, _, err := filterClause("plasmid_name==p123456", strainTermPage)
+	assert.Truef(
+		errors.Is(err, repository.ErrInvalidFilter),
+		"expect invalid filter error from plasmid field in strain filter",
+	)
+	_, _, err = filterClause("tag==REMI-seq", strainPage)
+	assert.Contains(
+		err.Error(), "filter field tag is not available in this list",
+		"should not refer to a variable the query does not bind",
+	)
+	_, _, err = filterClause("gene==DDB_G0348394", strainTermPage)
+	assert.Contains(
+		err.Error(), "operator == could not be used with array field gene",
+		"should not compare an array field with a single value",
+	)
+}
+
+func TestListFilterableFields(t *testing.T) {
+	t.Parallel()
+	assert := require.New(t)
+	repo := &arangorepository{}
+	ffs, err := repo.ListFilterableFields("plasmid")
+	assert.NoError(err, "expect no error from listing plasmid fields")
+	names := make([]string, 0, len(ffs))
+	for _, ff := range ffs {
+		names = append(names, ff.Name)
+		assert.NotEmptyf(ff.Operators, "field %s should have operators", ff.Name)
+	}
+	assert.Equal(
+		[]string{
+			"created_at", "depositor", "gene", "id",
+			"plasmid_name", "publication", "summary", "updated_at",
+		},
+		names,
+		"should list the plasmid fields in order",
+	)
+	ffs, err = repo.ListFilterableFields("strain")
+	assert.NoError(err, "expect no error from listing strain fields")
+	for _, ff := range ffs {
+		switch ff.Name {
+		case "created_at":
+			assert.Equal("date", ff.Type)
+			assert.Contains(ff.Operators, "BETWEEN")
+		case "name":
+			assert.Equal("array", ff.Type)
+			assert.Contains(ff.Operators, "ANY IN")
+		case "label":
+			assert.Equal("string", ff.Type)
+			assert.Contains(ff.Operators, "NOT IN")
+		}
+	}
+	_, err = repo.ListFilterableFields("oligo")
+	assert.Error(err, "expect error from unsupported stock type")
+}
@@ -0,0 +1,282 @@
+package arangodb
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/dictyBase/modware-stock/internal/repository"
+)
+
+var (
+	dateLayouts = []string{"2006-01-02", "2006-01", "2006"}
+	numericOps  = map[string]bool{">": true, "<": true, ">=": true, "<=": true}
+	filterOps   = map[string]string{"===": "==", "!==": "!="}
+	// quantOps are the operators that could be applied to any or to all
+	// the elements of an array field
+	quantOps = map[string]bool{
+		"==": true, "!=": true, "=~": true, "!~": true, "IN": true, "NOT IN": true,
+	}
+	dateFilterOp = map[string]string{
+		"$==": "==", "$>": ">", "$<": "<", "$>=": ">=", "$<=": "<=",
+	}
+	arrayFilterTmpl = map[string]string{
+		"@==": "%[2]s IN %[1]s[*]",
+		"@!=": "%[2]s NOT IN %[1]s[*]",
+		"@=~": `LENGTH((
+			FOR x IN %[1]s[*] FILTER CONTAINS(x, LOWER(%[2]s)) LIMIT 1 RETURN 1
+		)) > 0`,
+		"@!~": `LENGTH((
+			FOR x IN %[1]s[*] FILTER CONTAINS(x, LOWER(%[2]s)) LIMIT 1 RETURN 1
+		)) == 0`,
+	}
+)
+
+// filterClause converts a filter string into an AQL FILTER clause of the
+// list query pq. Only the database fields of the listed stock type and
+// bind parameter names are written into the clause, the filter values
+// are returned as bind parameters.
+// The groups and the logical operators of the filter are kept by
+// enclosing every joined expression within parentheses. An empty filter
+// string gives an empty clause.
+func filterClause(
+	fstr string,
+	pq pageQuery,
+) (string, map[string]interface{}, error) {
+	bindVars := make(map[string]interface{})
+	if len(strings.TrimSpace(fstr)) == 0 {
+		return "", bindVars, nil
+	}
+	node, err := parseFilter(fstr)
+	if err != nil {
+		return "", bindVars, err
+	}
+	fc := &filterCompiler{page: pq, bindVars: bindVars}
+	expr, err := fc.expr(node)
+	if err != nil {
+		return "", bindVars, err
+	}
+	return "FILTER " + expr, bindVars, nil
+}
+
+// filterCompiler writes the AQL expression of a parsed filter, numbering
+// the bind parameters of its values in order
+type filterCompiler struct {
+	page     pageQuery
+	bindVars map[string]interface{}
+	count    int
+}
+
+func (fc *filterCompiler) param(value interface{}) string {
+	name := fmt.Sprintf("filter%d", fc.count)
+	fc.count++
+	fc.bindVars[name] = value
+	return "@" + name
+}
+
+func (fc *filterCompiler) expr(node *filterNode) (string, error) {
+	switch node.kind {
+	case nodeCond:
+		return fc.condExpr(node.cond)
+	case nodeNot:
+		expr, err := fc.expr(node.nodes[0])
+		if err != nil {
+			return "", err
+		}
+		return "NOT (" + expr + ")", nil
+	}
+	sep := " AND "
+	if node.kind == nodeOr {
+		sep = " OR "
+	}
+	exprs := make([]string, 0, len(node.nodes))
+	for _, n := range node.nodes {
+		expr, err := fc.expr(n)
+		if err != nil {
+			return "", err
+		}
+		if n.kind == nodeAnd || n.kind == nodeOr {
+			expr = "(" + expr + ")"
+		}
+		exprs = append(exprs, expr)
+	}
+	return strings.Join(exprs, sep), nil
+}
+
+// condExpr gives the AQL expression of a single condition
+func (fc *filterCompiler) condExpr(cond *filterCond) (string, error) {
+	sf, err := fc.stockField(cond)
+	if err != nil {
+		return "", err
+	}
+	field := sf.expr
+	op := cond.operator
+	if alias, ok := filterOps[op]; ok {
+		op = alias
+	}
+	if len(cond.quant) > 0 {
+		return fc.quantExpr(cond, sf, op)
+	}
+	if !hasOperator(sf.kind, cond.operator) {
+		return "", errors.Wrapf(
+			repository.ErrInvalidFilter,
+			"operator %s could not be used with %s field %s at position %d, valid operators are %s",
+			cond.operator, sf.kind, cond.field, cond.pos,
+			strings.Join(kindOperators[sf.kind], ", "),
+		)
+	}
+	if tmpl, ok := arrayFilterTmpl[op]; ok {
+		return fmt.Sprintf(tmpl, field, fc.param(cond.values[0])), nil
+	}
+	if dop, ok := dateFilterOp[op]; ok {
+		if err := validateDates(cond); err != nil {
+			return "", err
+		}
+		return fmt.Sprintf(
+			"%s %s DATE_ISO8601(%s)", field, dop, fc.param(cond.values[0]),
+		), nil
+	}
+	switch op {
+	case "BETWEEN":
+		return fc.rangeExpr(cond, field)
+	case "IN", "NOT IN":
+		return fmt.Sprintf("%s %s %s", field, op, fc.param(cond.values)), nil
+	}
+	var value interface{} = cond.values[0]
+	if numericOps[op] {
+		if num, err := strconv.ParseFloat(cond.values[0], 64); err == nil {
+			value = num
+		}
+	}
+	return fmt.Sprintf("%s %s %s", field, op, fc.param(value)), nil
+}
+
+// stockField gives the field of the listed stock type that a condition
+// refers to
+func (fc *filterCompiler) stockField(cond *filterCond) (stockField, error) {
+	sf, ok := fc.page.fields[cond.field]
+	if !ok {
+		return sf, errors.Wrapf(
+			repository.ErrInvalidFilter,
+			"unsupported filter field %s at position %d, valid fields are %s",
+			cond.field, cond.pos, strings.Join(fieldNames(fc.page.fields), ", "),
+		)
+	}
+	if !fc.page.vars[sf.variable()] {
+		return sf, condError(
+			cond, "filter field %s is not available in this list", cond.field,
+		)
+	}
+	return sf, nil
+}
+
+func hasOperator(kind fieldKind, op string) bool {
+	for _, kop := range kindOperators[kind] {
+		if kop == op {
+			return true
+		}
+	}
+	return false
+}
+
+// quantExpr gives the expression that holds for any or for all of the
+// elements of an array field
+func (fc *filterCompiler) quantExpr(
+	cond *filterCond,
+	sf stockField,
+	op string,
+) (string, error) {
+	field := sf.expr
+	if sf.kind != arrayField {
+		return "", condError(cond, "%s(%s) needs an array field", cond.quant, cond.field)
+	}
+	if !quantOps[op] {
+		return "", condError(
+			cond, "operator %s could not be used with %s", cond.operator, cond.quant,
+		)
+	}
+	var value interface{} = cond.values[0]
+	if op == "IN" || op == "NOT IN" {
+		value = cond.values
+	}
+	elem := fmt.Sprintf("CURRENT %s %s", op, fc.param(value))
+	if cond.quant == "ALL" {
+		return fmt.Sprintf("LENGTH(%s[* FILTER NOT (%s)]) == 0", field, elem), nil
+	}
+	return fmt.Sprintf("LENGTH(%s[* FILTER %s]) > 0", field, elem), nil
+}
+
+// rangeExpr gives the expression for a date range, both of its dates are
+// included to the precision they are given in
+func (fc *filterCompiler) rangeExpr(
+	cond *filterCond,
+	field string,
+) (string, error) {
+	if len(cond.values) != 2 {
+		return "", condError(cond, "BETWEEN needs a list of two dates")
+	}
+	if err := validateDates(cond); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf(
+		"(%[1]s >= DATE_ISO8601(%[2]s) AND %[1]s < DATE_ISO8601(%[3]s))",
+		field, fc.param(cond.values[0]), fc.param(dateAfter(cond.values[1])),
+	), nil
+}
+
+func validateDates(cond *filterCond) error {
+	for _, v := range cond.values {
+		if !isFilterDate(v) {
+			return condError(
+				cond, "invalid date %s for filter field %s", v, cond.field,
+			)
+		}
+	}
+	return nil
+}
+
+func condError(cond *filterCond, format string, args ...interface{}) error {
+	return errors.Wrapf(
+		repository.ErrInvalidFilter,
+		"%s at position %d", fmt.Sprintf(format, args...), cond.pos,
+	)
+}
+
+func isFilterDate(value string) bool {
+	for _, layout := range dateLayouts {
+		if _, err := time.Parse(layout, value); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// dateAfter gives the date that follows the period of a day, month or
+// year given by value
+func dateAfter(value string) string {
+	for i, layout := range dateLayouts {
+		t, err := time.Parse(layout, value)
+		if err != nil {
+			continue
+		}
+		switch i {
+		case 0:
+			t = t.AddDate(0, 0, 1)
+		case 1:
+			t = t.AddDate(0, 1, 0)
+		default:
+			t = t.AddDate(1, 0, 0)
+		}
+		return t.Format(dateLayouts[0])
+	}
+	return value
+}
+
+// mergeBindVars copies all the filter bind parameters into bindVars
+func mergeBindVars(bindVars, filterVars map[string]interface{}) {
+	for k, v := range filterVars {
+		bindVars[k] = v
+	}
+}
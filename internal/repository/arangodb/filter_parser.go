@@ -0,0 +1,333 @@
+package arangodb
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/cockroachdb/errors"
+	"github.com/dictyBase/modware-stock/internal/repository"
+)
+
+// The filter language extends the field, operator and value conditions of
+// the earlier filter strings, where ; joins the conditions by AND and ,
+// joins them by OR, the OR taking precedence.
+//
+//	filter    = and
+//	and       = or { ";" or }
+//	or        = unary { "," unary }
+//	unary     = "!" unary | "(" and ")" | condition
+//	condition = target operator value
+//	          | target ( "IN" | "NOT IN" ) list
+//	          | field "BETWEEN" "[" value "," value "]"
+//	target    = field | ( "ANY" | "ALL" ) "(" field ")"
+//	list      = "[" value { "," value } "]"
+//	value     = quoted | unquoted
+//
+// An unquoted value could have letters, digits, spaces and any of _-@./:+
+// whereas a double quoted value could have any character, with \ escaping
+// the quote and itself.
+
+type nodeKind int
+
+const (
+	nodeCond nodeKind = iota
+	nodeAnd
+	nodeOr
+	nodeNot
+)
+
+// filterNode is a parsed filter expression, either a condition or a
+// logical operator over its nodes
+type filterNode struct {
+	kind  nodeKind
+	nodes []*filterNode
+	cond  *filterCond
+}
+
+// filterCond is a single condition of a filter
+type filterCond struct {
+	// pos is the position of the condition in the filter string,
+	// starting from one
+	pos   int
+	field string
+	// quant is either ANY or ALL for the conditions over the elements
+	// of an array field
+	quant    string
+	operator string
+	values   []string
+}
+
+// filterOperators are the symbolic operators, the longer ones come
+// before their prefixes
+var filterOperators = []string{
+	"===", "!==", "$==", "$>=", "$<=", "@==", "@!=", "@=~", "@!~",
+	"==", "!=", "=~", "!~", ">=", "<=", "$>", "$<", ">", "<",
+}
+
+type filterParser struct {
+	input []rune
+	pos   int
+}
+
+// parseFilter parses a filter string into a tree of conditions, the
+// errors give the position in the string where parsing failed
+func parseFilter(fstr string) (*filterNode, error) {
+	p := &filterParser{input: []rune(fstr)}
+	node, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpace()
+	if !p.eof() {
+		return nil, p.errorf("unexpected %q", p.peek())
+	}
+	return node, nil
+}
+
+func (p *filterParser) errorf(format string, args ...interface{}) error {
+	return errors.Wrapf(
+		repository.ErrInvalidFilter,
+		"%s at position %d", fmt.Sprintf(format, args...), p.pos+1,
+	)
+}
+
+func (p *filterParser) eof() bool {
+	return p.pos >= len(p.input)
+}
+
+func (p *filterParser) peek() rune {
+	if p.eof() {
+		return 0
+	}
+	return p.input[p.pos]
+}
+
+func (p *filterParser) skipSpace() {
+	for !p.eof() && unicode.IsSpace(p.peek()) {
+		p.pos++
+	}
+}
+
+func (p *filterParser) parseAnd() (*filterNode, error) {
+	return p.parseJoined(';', nodeAnd, p.parseOr)
+}
+
+func (p *filterParser) parseOr() (*filterNode, error) {
+	return p.parseJoined(',', nodeOr, p.parseUnary)
+}
+
+// parseJoined parses the expressions separated by sep, more than one of
+// them are joined into a node of the given kind
+func (p *filterParser) parseJoined(
+	sep rune,
+	kind nodeKind,
+	parse func() (*filterNode, error),
+) (*filterNode, error) {
+	var nodes []*filterNode
+	for {
+		node, err := parse()
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, node)
+		p.skipSpace()
+		if p.peek() != sep {
+			break
+		}
+		p.pos++
+	}
+	if len(nodes) == 1 {
+		return nodes[0], nil
+	}
+	return &filterNode{kind: kind, nodes: nodes}, nil
+}
+
+func (p *filterParser) parseUnary() (*filterNode, error) {
+	p.skipSpace()
+	switch p.peek() {
+	case 0:
+		return nil, p.errorf("missing condition")
+	case '!':
+		p.pos++
+		node, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &filterNode{kind: nodeNot, nodes: []*filterNode{node}}, nil
+	case '(':
+		open := p.pos
+		p.pos++
+		node, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		p.skipSpace()
+		if p.peek() != ')' {
+			return nil, p.errorf(
+				"missing ) for the group opened at position %d", open+1,
+			)
+		}
+		p.pos++
+		return node, nil
+	}
+	cond, err := p.parseCond()
+	if err != nil {
+		return nil, err
+	}
+	return &filterNode{kind: nodeCond, cond: cond}, nil
+}
+
+func (p *filterParser) parseCond() (*filterCond, error) {
+	cond := &filterCond{pos: p.pos + 1}
+	ident := p.readIdent()
+	if len(ident) == 0 {
+		return nil, p.errorf("expected a field but found %q", p.peek())
+	}
+	cond.field = ident
+	if q := strings.ToUpper(ident); (q == "ANY" || q == "ALL") && p.peek() == '(' {
+		p.pos++
+		p.skipSpace()
+		cond.quant, cond.field = q, p.readIdent()
+		if len(cond.field) == 0 {
+			return nil, p.errorf("expected a field but found %q", p.peek())
+		}
+		p.skipSpace()
+		if p.peek() != ')' {
+			return nil, p.errorf("missing ) after field %s", cond.field)
+		}
+		p.pos++
+	}
+	p.skipSpace()
+	op, err := p.readOperator(cond.field)
+	if err != nil {
+		return nil, err
+	}
+	cond.operator = op
+	switch op {
+	case "IN", "NOT IN", "BETWEEN":
+		cond.values, err = p.parseList()
+	default:
+		var v string
+		v, err = p.parseValue(cond.field, false)
+		cond.values = []string{v}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return cond, nil
+}
+
+func (p *filterParser) readIdent() string {
+	start := p.pos
+	for !p.eof() {
+		r := p.peek()
+		if !(r == '_' || r < unicode.MaxASCII && (unicode.IsLetter(r) ||
+			(p.pos > start && unicode.IsDigit(r)))) {
+			break
+		}
+		p.pos++
+	}
+	return string(p.input[start:p.pos])
+}
+
+func (p *filterParser) readOperator(field string) (string, error) {
+	rest := string(p.input[p.pos:])
+	for _, op := range filterOperators {
+		if strings.HasPrefix(rest, op) {
+			p.pos += len(op)
+			return op, nil
+		}
+	}
+	start := p.pos
+	switch word := strings.ToUpper(p.readIdent()); word {
+	case "IN", "BETWEEN":
+		return word, nil
+	case "NOT":
+		p.skipSpace()
+		if strings.ToUpper(p.readIdent()) == "IN" {
+			return "NOT IN", nil
+		}
+	}
+	p.pos = start
+	return "", p.errorf("expected an operator after field %s", field)
+}
+
+// parseList parses a bracketed list of values
+func (p *filterParser) parseList() ([]string, error) {
+	p.skipSpace()
+	if p.peek() != '[' {
+		return nil, p.errorf("expected [ to start a list")
+	}
+	p.pos++
+	var values []string
+	for {
+		v, err := p.parseValue("list", true)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, v)
+		p.skipSpace()
+		switch p.peek() {
+		case ',':
+			p.pos++
+			continue
+		case ']':
+			p.pos++
+			return values, nil
+		}
+		return nil, p.errorf("missing ] at the end of the list")
+	}
+}
+
+// parseValue reads a quoted or an unquoted value. An unquoted value ends
+// at any of the separators, a list item also ends at ].
+func (p *filterParser) parseValue(field string, item bool) (string, error) {
+	p.skipSpace()
+	if p.peek() == '"' {
+		return p.parseQuoted()
+	}
+	start := p.pos
+	for !p.eof() {
+		r := p.peek()
+		if r == ';' || r == ',' || r == ')' || (item && r == ']') {
+			break
+		}
+		if !isValueRune(r) {
+			return "", p.errorf("unexpected %q in value, quote the value to use it", r)
+		}
+		p.pos++
+	}
+	v := strings.TrimSpace(string(p.input[start:p.pos]))
+	if len(v) == 0 {
+		return "", p.errorf("missing value for %s", field)
+	}
+	return v, nil
+}
+
+func (p *filterParser) parseQuoted() (string, error) {
+	open := p.pos
+	p.pos++
+	var bldr strings.Builder
+	for !p.eof() {
+		r := p.peek()
+		p.pos++
+		switch r {
+		case '"':
+			return bldr.String(), nil
+		case '\\':
+			if p.eof() {
+				break
+			}
+			r = p.peek()
+			p.pos++
+		}
+		bldr.WriteRune(r)
+	}
+	return "", p.errorf("missing \" for the value quoted at position %d", open+1)
+}
+
+func isValueRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == ' ' ||
+		strings.ContainsRune("_-@./:+", r)
+}
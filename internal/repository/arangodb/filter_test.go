@@ -0,0 +1,213 @@
+package arangodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/dictyBase/modware-stock/internal/model"
+	"github.com/dictyBase/modware-stock/internal/repository"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilterClause(t *testing.T) {
+	t.Parallel()
+	assert := require.New(t)
+	clause, bindVars, err := filterClause("", strainTermPage)
+	assert.NoError(err, "expect no error from empty filter")
+	assert.Empty(clause, "should give empty clause for empty filter")
+	assert.Empty(bindVars, "should give no bind parameters for empty filter")
+	clause, bindVars, err = filterClause(filterAllStrain, strainTermPage)
+	assert.NoError(err, "expect no error from filter with OR group")
+	assert.Equal(
+		"FILTER cv.metadata.namespace == @filter0 AND (cvterm.label == @filter1 OR cvterm.label == @filter2 OR cvterm.label == @filter3)",
+		clause,
+		"should group OR expressions within AND",
+	)
+	assert.Equal("dicty_strain_property", bindVars["filter0"])
+	assert.Equal("REMI-seq", bindVars["filter2"])
+	clause, bindVars, err = filterClause(filterFour, strainTermPage)
+	assert.NoError(err, "expect no error from date filter")
+	assert.Equal(
+		"FILTER s.created_at <= DATE_ISO8601(@filter0)",
+		clause,
+		"should bind date value",
+	)
+	assert.Equal("2019", bindVars["filter0"])
+	clause, bindVars, err = filterClause(filterThree, strainTermPage)
+	assert.NoError(err, "expect no error from array filter")
+	assert.Equal("FILTER @filter0 IN stock_prop.names[*]", clause)
+	assert.Equal("gammaS13", bindVars["filter0"])
+	for _, fstr := range []string{
+		"summary==mutant RETURN s",
+		"label=~yS REMOVE s IN stock",
+		"depositor==george@costanza.com",
+	} {
+		clause, bindVars, err := filterClause(fstr, strainTermPage)
+		assert.NoErrorf(err, "expect no error from filter %s", fstr)
+		for _, v := range bindVars {
+			assert.NotContainsf(
+				clause, v,
+				"filter value %s should not be part of the statement", v,
+			)
+		}
+	}
+	for _, fstr := range []string{
+		filterBad,
+		"borat==funny",
+		"depositor==george' OR 'a'=='a",
+		"summary=~mutant') RETURN s //",
+		"created_at$<=yesterday",
+		"depositor==george@costanza.com;id==x\" OR true",
+	} {
+		_, _, err := filterClause(fstr, strainTermPage)
+		assert.Errorf(err, "expect error from filter %s", fstr)
+		assert.Truef(
+			errors.Is(err, repository.ErrInvalidFilter),
+			"expect invalid filter error from filter %s", fstr,
+		)
+	}
+}
+
+func TestListStocksWithMaliciousFilter(t *testing.T) {
+	t.Parallel()
+	assert, repo := setUp(t)
+	defer tearDown(repo)
+	err := createTestStrains(10, General, repo)
+	assert.NoError(err, "expect no error from creating strains")
+	for _, fstr := range []string{
+		"summary==Radiation-sensitive mutant. RETURN s",
+		"label=~yS REMOVE s IN stock",
+		"depositor==george@costanza.com FILTER true",
+	} {
+		ls, err := repo.ListStrains(
+			context.Background(),
+			&model.ListParams{Limit: 10, Filter: fstr},
+		)
+		assert.NoErrorf(err, "expect no error from filter %s", fstr)
+		assert.Lenf(ls, 0, "should match filter %s only as value", fstr)
+	}
+	for _, fstr := range []string{
+		"depositor==george@costanza.com' OR 'a'=='a",
+		"summary=~mutant') RETURN s //",
+		"depositor==x\"; FOR d IN stock REMOVE d IN stock",
+	} {
+		_, err := repo.ListStrains(
+			context.Background(),
+			&model.ListParams{Limit: 10, Filter: fstr},
+		)
+		assert.Truef(
+			errors.Is(err, repository.ErrInvalidFilter),
+			"expect invalid filter error from filter %s", fstr,
+		)
+		_, err = repo.ListPlasmids(
+			context.Background(),
+			&model.ListParams{Limit: 10, Filter: fstr},
+		)
+		assert.Truef(
+			errors.Is(err, repository.ErrInvalidFilter),
+			"expect invalid filter error from filter %s", fstr,
+		)
+	}
+	ls, err := repo.ListStrains(
+		context.Background(),
+		&model.ListParams{Limit: 20},
+	)
+	assert.NoError(err, "expect no error from listing strains")
+	assert.Len(ls, 10, "should keep all strains after malicious filters")
+	assert.True(
+		strings.HasPrefix(ls[0].StrainProperties.Label, "yS"),
+		"should match label of test strain",
+	)
+}
+
+func TestFilterClauseGrammar(t *testing.T) {
+	t.Parallel()
+	assert := require.New(t)
+	for fstr, expected := range map[string]string{
+		"(species==Dictyostelium discoideum,species==Dictyostelium purpureum);gene@=~DDB_G03": "FILTER (stock_prop.species == @filter0 OR stock_prop.species == @filter1) AND LENGTH((\n\t\t\tFOR x IN s.genes[*] FILTER CONTAINS(x, LOWER(@filter2)) LIMIT 1 RETURN 1\n\t\t)) > 0",
+		"id IN [DBS0236123, DBS0350768]":               "FILTER s.stock_id IN @filter0",
+		"id not in [DBS0236123]":                       "FILTER s.stock_id NOT IN @filter0",
+		"!(label==AX4;depositor==george@costanza.com)": "FILTER NOT (stock_prop.label == @filter0 AND s.depositor == @filter1)",
+		"created_at BETWEEN [2019-01, 2019-06-30]":     "FILTER (s.created_at >= DATE_ISO8601(@filter0) AND s.created_at < DATE_ISO8601(@filter1))",
+		"ANY(gene)==DDB_G0348394":                      "FILTER LENGTH(s.genes[* FILTER CURRENT == @filter0]) > 0",
+		"ALL(publication) IN [83943, 48428304983]":     "FILTER LENGTH(s.publications[* FILTER NOT (CURRENT IN @filter0)]) == 0",
+		`summary=="mutant (null); \"cAMP\""`:           "FILTER s.summary == @filter0",
+	} {
+		clause, _, err := filterClause(fstr, strainTermPage)
+		assert.NoErrorf(err, "expect no error from filter %s", fstr)
+		assert.Equalf(expected, clause, "should convert filter %s", fstr)
+	}
+	_, bindVars, err := filterClause(
+		"id IN [DBS0236123, \"DBS0350768\"]", strainTermPage,
+	)
+	assert.NoError(err, "expect no error from IN filter")
+	assert.Equal([]string{"DBS0236123", "DBS0350768"}, bindVars["filter0"])
+	_, bindVars, err = filterClause(
+		"created_at BETWEEN [2019-01, 2019-06-30]", strainTermPage,
+	)
+	assert.NoError(err, "expect no error from date range filter")
+	assert.Equal("2019-01", bindVars["filter0"])
+	assert.Equal("2019-07-01", bindVars["filter1"], "should include the last day")
+	_, bindVars, err = filterClause(
+		`summary=="mutant (null); \"cAMP\""`, strainTermPage,
+	)
+	assert.NoError(err, "expect no error from quoted value")
+	assert.Equal(`mutant (null); "cAMP"`, bindVars["filter0"])
+	for fstr, msg := range map[string]string{
+		"(label==AX4;species==x":              "missing ) for the group opened at position 1 at position 23",
+		"label==AX4)":                         `unexpected ')' at position 11`,
+		"label==AX4;;":                        `expected a field but found ';' at position 12`,
+		"label AX4":                           "expected an operator after field label at position 7",
+		"id IN DBS0236123":                    "expected [ to start a list at position 7",
+		"id IN [DBS0236123":                   "missing ] at the end of the list at position 18",
+		`summary=="mutant`:                    `missing " for the value quoted at position 10 at position 17`,
+		"label==AX4;borat==funny":             "unsupported filter field borat at position 12",
+		"ANY(label)==AX4":                     "ANY(label) needs an array field at position 1",
+		"ANY(gene)>2":                         "operator > could not be used with ANY at position 1",
+		"summary$<=2019":                      "operator $<= could not be used with string field summary at position 1, valid operators are ==, !=",
+		"created_at BETWEEN [2019]":           "BETWEEN needs a list of two dates at position 1",
+		"created_at BETWEEN [2019, tomorrow]": "invalid date tomorrow for filter field created_at at position 1",
+		"label==":                             "missing value for label at position 8",
+	} {
+		_, _, err := filterClause(fstr, strainTermPage)
+		assert.Truef(
+			errors.Is(err, repository.ErrInvalidFilter),
+			"expect invalid filter error from filter %s", fstr,
+		)
+		assert.Containsf(err.Error(), msg, "should give position of error in %s", fstr)
+	}
+}
+
+func TestListStrainsWithFilterGrammar(t *testing.T) {
+	t.Parallel()
+	assert, repo := setUp(t)
+	defer tearDown(repo)
+	err := createTestStrains(10, General, repo)
+	assert.NoError(err, "expect no error from creating strains")
+	all, err := repo.ListStrains(context.Background(), &model.ListParams{Limit: 20})
+	assert.NoError(err, "expect no error from listing strains")
+	for fstr, count := range map[string]int{
+		fmt.Sprintf("id IN [%s, %s]", all[0].StockID, all[1].StockID):            2,
+		fmt.Sprintf("!(id IN [%s, %s])", all[0].StockID, all[1].StockID):         8,
+		fmt.Sprintf("id NOT IN [%s]", all[0].StockID):                            9,
+		"(species==Dictyostelium discoideum,species==x);ANY(gene)=~\"^DDB_G03\"": 10,
+		`ALL(gene)=~"^DDB_G0348"`:                                                0,
+		"ALL(name) IN [gammaS13, gammaS-13, γS-13]":                              10,
+		"ANY(publication)==4849343943;depositor!=george@costanza.com":            0,
+		"created_at BETWEEN [2010, 2100-12-31]":                                  10,
+		"updated_at BETWEEN [2010, 2011]":                                        0,
+	} {
+		ls, err := repo.ListStrains(
+			context.Background(),
+			&model.ListParams{Limit: 20, Filter: fstr},
+		)
+		assert.NoErrorf(err, "expect no error from filter %s", fstr)
+		assert.Lenf(ls, count, "should match %d strains with filter %s", count, fstr)
+		n, err := repo.CountStrains(context.Background(), fstr)
+		assert.NoErrorf(err, "expect no error from counting with filter %s", fstr)
+		assert.Equalf(int64(count), n, "should count strains with filter %s", fstr)
+	}
+}
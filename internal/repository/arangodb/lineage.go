@@ -0,0 +1,175 @@
+package arangodb
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cockroachdb/errors"
+	"github.com/dictyBase/modware-stock/internal/model"
+	"github.com/dictyBase/modware-stock/internal/repository"
+	"github.com/dictyBase/modware-stock/internal/repository/arangodb/statement"
+)
+
+// maxLineageDepth is the largest number of generations a lineage is
+// traversed for, it is also the depth of a traversal without any
+const maxLineageDepth = 100
+
+// lineageDirections are the directions of the parent edges that lead to
+// the relatives of a strain, the edges go from the parent to the child
+var lineageDirections = map[string]string{
+	model.LineageAncestors:   "INBOUND",
+	model.LineageDescendants: "OUTBOUND",
+}
+
+var lineagePage = pageQuery{
+	vars: map[string]bool{"s": true},
+	key:  "s._key",
+}
+
+// ListStrainLineage lists the ancestors or the descendants of a strain up
+// to the given depth, the nearest generation first. Only the parents of the
+// given relationships are followed. It fails with
+// repository.ErrStockNotFound if the strain does not exist.
+func (ar *arangorepository) ListStrainLineage(
+	ctx context.Context,
+	p *model.LineageParams,
+) ([]*model.StrainRelative, error) {
+	relatives := make([]*model.StrainRelative, 0)
+	direction, ok := lineageDirections[p.Direction]
+	if !ok {
+		return relatives, errors.Errorf(
+			"unsupported lineage direction %s", p.Direction,
+		)
+	}
+	tx := ar.directTx(ctx)
+	if err := ar.checkLineageRoot(tx, p.ID); err != nil {
+		return relatives, err
+	}
+	bindVars := ar.lineageBindParams(p)
+	bindVars["limit"] = p.Limit + 1
+	page := ""
+	if p.Cursor != nil {
+		cond, err := lineagePage.cursorCondition(
+			p.Cursor,
+			[]string{"sort_values[0]"},
+			[]string{">"},
+			bindVars,
+		)
+		if err != nil {
+			return relatives, err
+		}
+		page = "FILTER " + cond
+	}
+	proj, err := ar.project(strainProjection, p.Fields, true, bindVars)
+	if err != nil {
+		return relatives, err
+	}
+	relatives, err = searchRows[model.StrainRelative](
+		tx,
+		fmt.Sprintf(
+			statement.StrainLineage,
+			direction, lineageFilter(p, bindVars), page, proj,
+		),
+		bindVars,
+	)
+	if err != nil {
+		return relatives, errors.Errorf(
+			"error in listing %s of strain %s %s", p.Direction, p.ID, err,
+		)
+	}
+	return relatives, nil
+}
+
+// GetStrainLineageGraph gives the ancestors or the descendants of a strain
+// up to the given depth along with the parent edges between them. Only the
+// parents of the given relationships are followed. It fails with
+// repository.ErrStockNotFound if the strain does not exist.
+func (ar *arangorepository) GetStrainLineageGraph(
+	ctx context.Context,
+	p *model.LineageParams,
+) (*model.LineageGraph, error) {
+	g := &model.LineageGraph{Root: p.ID, Direction: p.Direction}
+	direction, ok := lineageDirections[p.Direction]
+	if !ok {
+		return g, errors.Errorf(
+			"unsupported lineage direction %s", p.Direction,
+		)
+	}
+	tx := ar.directTx(ctx)
+	if err := ar.checkLineageRoot(tx, p.ID); err != nil {
+		return g, err
+	}
+	bindVars := mergeBindParams(ar.lineageBindParams(p), map[string]interface{}{
+		"stock_cvterm_graph": ar.stockc.stockOnto.Name(),
+		"@cv_collection":     ar.ontoc.Cv.Name(),
+		"ontology":           ar.strainOnto,
+	})
+	_, err := tx.getRow(
+		fmt.Sprintf(
+			statement.StrainLineageGraph,
+			direction, lineageFilter(p, bindVars), termExpr,
+		),
+		bindVars, g,
+	)
+	if err != nil {
+		return g, errors.Errorf(
+			"error in getting %s of strain %s %s", p.Direction, p.ID, err,
+		)
+	}
+	return g, nil
+}
+
+func (ar *arangorepository) checkLineageRoot(tx *dbTx, id string) error {
+	var sid string
+	found, err := tx.getRow(
+		statement.StockFindQ,
+		map[string]interface{}{
+			"@stock_collection": ar.stockc.stock.Name(),
+			"id":                id,
+		}, &sid)
+	if err != nil {
+		return errors.Errorf("error in searching for strain %s %s", id, err)
+	}
+	if !found {
+		return errors.Wrapf(
+			repository.ErrStockNotFound, "strain %s is not found", id,
+		)
+	}
+	return nil
+}
+
+func (ar *arangorepository) lineageBindParams(
+	p *model.LineageParams,
+) map[string]interface{} {
+	depth := p.Depth
+	if depth <= 0 || depth > maxLineageDepth {
+		depth = maxLineageDepth
+	}
+	return map[string]interface{}{
+		"id":               p.ID,
+		"depth":            depth,
+		"stock_collection": ar.stockc.stock.Name(),
+		"parent_graph":     ar.stockc.strain2Parent.Name(),
+		"stock_prop_graph": ar.stockc.stockPropType.Name(),
+	}
+}
+
+// lineageFilter gives the filter of the traversed edges by the
+// relationships of the lineage, none without any
+func lineageFilter(
+	p *model.LineageParams,
+	bindVars map[string]interface{},
+) string {
+	if len(p.Relationships) == 0 {
+		return ""
+	}
+	relationships := make([]interface{}, 0, len(p.Relationships)+1)
+	for _, r := range p.Relationships {
+		relationships = append(relationships, r)
+		if r == model.DerivedFrom {
+			relationships = append(relationships, nil)
+		}
+	}
+	bindVars["relationships"] = relationships
+	return statement.LineageRelationshipFilter
+}
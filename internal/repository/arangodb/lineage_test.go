@@ -0,0 +1,164 @@
+package arangodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/dictyBase/modware-stock/internal/model"
+	"github.com/dictyBase/modware-stock/internal/repository"
+)
+
+// addTestChild adds a strain derived from the parent and gives its id
+func addTestChild(repo repository.StockRepository, parent string) (string, error) {
+	ns := newTestStrain("george@costanza.com", General)
+	ns.Data.Attributes.Parent = parent
+	m, err := repo.AddStrain(context.Background(), ns)
+	if err != nil {
+		return "", err
+	}
+	return m.StockID, nil
+}
+
+func TestListStrainLineage(t *testing.T) {
+	t.Parallel()
+	assert, repo := setUp(t)
+	defer tearDown(repo)
+	root, err := repo.AddStrain(context.Background(), newTestParentStrain("j@peterman.org"))
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	child, err := addTestChild(repo, root.StockID)
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	sibling, err := addTestChild(repo, root.StockID)
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	grandchild, err := addTestChild(repo, child)
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	dl, err := repo.ListStrainLineage(context.Background(), &model.LineageParams{
+		ID:        root.StockID,
+		Direction: model.LineageDescendants,
+		Limit:     10,
+	})
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	assert.Len(dl, 3, "should list all the descendants")
+	assert.Equal(1, dl[0].Depth, "should list the children first")
+	assert.Equal(1, dl[1].Depth, "should list the children first")
+	assert.ElementsMatch(
+		[]string{child, sibling},
+		[]string{dl[0].Strain.StockID, dl[1].Strain.StockID},
+		"should list both the children",
+	)
+	assert.Equal(grandchild, dl[2].Strain.StockID, "should list the grandchild last")
+	assert.Equal(2, dl[2].Depth, "should be two generations apart")
+	assert.Equal(
+		[]string{root.StockID, child, grandchild},
+		dl[2].Path,
+		"should give the path from the root",
+	)
+	dl, err = repo.ListStrainLineage(context.Background(), &model.LineageParams{
+		ID:        root.StockID,
+		Direction: model.LineageDescendants,
+		Depth:     1,
+		Limit:     10,
+	})
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	assert.Len(dl, 2, "should only list the children within the depth")
+	pl, err := repo.ListStrainLineage(context.Background(), &model.LineageParams{
+		ID:        root.StockID,
+		Direction: model.LineageDescendants,
+		Limit:     1,
+	})
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	assert.Len(pl, 2, "should fetch one extra descendant to tell about more")
+	nl, err := repo.ListStrainLineage(context.Background(), &model.LineageParams{
+		ID:        root.StockID,
+		Direction: model.LineageDescendants,
+		Cursor: &model.StockCursor{
+			Values: pl[0].Strain.SortValues,
+			Key:    pl[0].Strain.Key,
+		},
+		Limit: 10,
+	})
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	assert.Len(nl, 2, "should continue after the cursor")
+	assert.NotEqual(pl[0].Strain.StockID, nl[0].Strain.StockID)
+	al, err := repo.ListStrainLineage(context.Background(), &model.LineageParams{
+		ID:        grandchild,
+		Direction: model.LineageAncestors,
+		Limit:     10,
+		Fields:    []string{"label"},
+	})
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	assert.Len(al, 2, "should list the parent and the grandparent")
+	assert.Equal(child, al[0].Strain.StockID, "should list the parent first")
+	assert.Equal(root.StockID, al[1].Strain.StockID, "should list the grandparent")
+	assert.Equal(
+		root.StrainProperties.Label, al[1].Strain.StrainProperties.Label,
+		"should match the label of the grandparent",
+	)
+	_, err = repo.ListStrainLineage(context.Background(), &model.LineageParams{
+		ID:        "DBS01099991",
+		Direction: model.LineageAncestors,
+		Limit:     10,
+	})
+	assert.True(
+		errors.Is(err, repository.ErrStockNotFound),
+		"expect not found error from nonexistent strain",
+	)
+}
+
+func TestGetStrainLineageGraph(t *testing.T) {
+	t.Parallel()
+	assert, repo := setUp(t)
+	defer tearDown(repo)
+	root, err := repo.AddStrain(context.Background(), newTestParentStrain("j@peterman.org"))
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	other, err := repo.AddStrain(context.Background(), newTestParentStrain("j@peterman.org"))
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	child, err := addTestChild(repo, root.StockID)
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	cross, err := addTestChild(repo, fmt.Sprintf(
+		"%s:crossed_from,%s:crossed_from", child, other.StockID,
+	))
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	g, err := repo.GetStrainLineageGraph(context.Background(), &model.LineageParams{
+		ID:        root.StockID,
+		Direction: model.LineageDescendants,
+	})
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	assert.Equal(root.StockID, g.Root, "should match the root")
+	assert.Len(g.Nodes, 3, "should have the root and its descendants")
+	assert.Equal(
+		[]*model.LineageEdge{
+			{Parent: root.StockID, Child: child, Relationship: model.DerivedFrom},
+			{Parent: child, Child: cross, Relationship: model.CrossedFrom},
+		},
+		g.Edges,
+		"should have the parent edges of the descendants",
+	)
+	for _, n := range g.Nodes {
+		assert.NotEmpty(n.Label, "should label the strain")
+		assert.NotEmpty(n.DictyStrainProperty, "should have the strain property")
+	}
+	g, err = repo.GetStrainLineageGraph(context.Background(), &model.LineageParams{
+		ID:        cross,
+		Direction: model.LineageAncestors,
+	})
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	assert.Len(g.Nodes, 4, "should have the strain and its ancestors")
+	assert.Len(g.Edges, 3, "should have the parent edges of the ancestors")
+	g, err = repo.GetStrainLineageGraph(context.Background(), &model.LineageParams{
+		ID:            cross,
+		Direction:     model.LineageAncestors,
+		Relationships: []string{model.CrossedFrom},
+	})
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	assert.Len(g.Edges, 2, "should only follow the crossed from parents")
+	_, err = repo.GetStrainLineageGraph(context.Background(), &model.LineageParams{
+		ID:        "DBS01099991",
+		Direction: model.LineageAncestors,
+	})
+	assert.True(
+		errors.Is(err, repository.ErrStockNotFound),
+		"expect not found error from nonexistent strain",
+	)
+}
@@ -0,0 +1,127 @@
+package arangodb
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"github.com/dictyBase/modware-stock/internal/repository"
+	"github.com/dictyBase/modware-stock/internal/repository/arangodb/statement"
+)
+
+// parentEdge is a parent edge between the stock ids of the parent and the
+// child
+type parentEdge struct {
+	Parent string `json:"parent"`
+	Child  string `json:"child"`
+}
+
+// checkParentCycle fails with repository.ErrParentCycle when the parent,
+// whose document id is pid, is already a descendant of the strain. It is
+// run within withParentLock, so that no other parent edge is added before
+// the checked one.
+func (ar *arangorepository) checkParentCycle(
+	tx *dbTx,
+	id, parent, pid string,
+) error {
+	path, err := searchRows[string](
+		tx,
+		statement.StrainDescendantPath,
+		map[string]interface{}{
+			"key":              id,
+			"descendant":       pid,
+			"stock_collection": ar.stockc.stock.Name(),
+			"parent_graph":     ar.stockc.strain2Parent.Name(),
+		})
+	if err != nil {
+		return errors.Errorf(
+			"error in searching for path from strain %s to %s %s",
+			id, parent, err,
+		)
+	}
+	if len(path) == 0 {
+		return nil
+	}
+	cycle := make([]string, 0, len(path)+1)
+	for _, v := range path {
+		cycle = append(cycle, *v)
+	}
+	return errors.Wrapf(
+		repository.ErrParentCycle,
+		"parent %s is a descendant of strain %s, cycle %s",
+		parent, id, formatCycle(append(cycle, id)),
+	)
+}
+
+// ListParentCycles gives every cycle of the parent edges, each as the
+// stock ids from a parent down to its child and on until the first of
+// them is reached again
+func (ar *arangorepository) ListParentCycles(
+	ctx context.Context,
+) ([][]string, error) {
+	edges, err := searchRows[parentEdge](
+		ar.directTx(ctx),
+		statement.ParentEdgesQ,
+		map[string]interface{}{
+			"@parent_strain_collection": ar.stockc.parentStrain.Name(),
+		})
+	if err != nil {
+		return nil, errors.Errorf("error in listing parent edges %s", err)
+	}
+	return findCycles(edges), nil
+}
+
+// findCycles gives a cycle for every edge that leads back to a strain
+// that is being visited by a depth first search of the children
+func findCycles(edges []*parentEdge) [][]string {
+	children := make(map[string][]string)
+	for _, e := range edges {
+		children[e.Parent] = append(children[e.Parent], e.Child)
+	}
+	strains := make([]string, 0, len(children))
+	for p := range children {
+		strains = append(strains, p)
+		sort.Strings(children[p])
+	}
+	sort.Strings(strains)
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make(map[string]int)
+	cycles := make([][]string, 0)
+	var path []string
+	var visit func(string)
+	visit = func(s string) {
+		state[s] = visiting
+		path = append(path, s)
+		for _, c := range children[s] {
+			switch state[c] {
+			case unvisited:
+				visit(c)
+			case visiting:
+				for i := len(path) - 1; i >= 0; i-- {
+					if path[i] == c {
+						cycle := append([]string{}, path[i:]...)
+						cycles = append(cycles, append(cycle, c))
+						break
+					}
+				}
+			}
+		}
+		path = path[:len(path)-1]
+		state[s] = visited
+	}
+	for _, s := range strains {
+		if state[s] == unvisited {
+			visit(s)
+		}
+	}
+	return cycles
+}
+
+func formatCycle(cycle []string) string {
+	return strings.Join(cycle, " -> ")
+}
@@ -0,0 +1,333 @@
+package arangodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/dictyBase/go-genproto/dictybaseapis/stock"
+	"github.com/dictyBase/modware-stock/internal/model"
+	"github.com/dictyBase/modware-stock/internal/repository"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseParents(t *testing.T) {
+	t.Parallel()
+	assert := require.New(t)
+	parents, err := model.ParseParents("DBS0236123, DBS0236124:crossed_from")
+	assert.NoError(err, "expect no error from parsing the parents")
+	assert.Equal(
+		[]*model.StrainParent{
+			{ID: "DBS0236123", Relationship: model.DerivedFrom},
+			{ID: "DBS0236124", Relationship: model.CrossedFrom},
+		},
+		parents,
+		"should read the parents along with their relationships",
+	)
+	assert.Equal(
+		"DBS0236123,DBS0236124:crossed_from", model.FormatParents(parents),
+		"should leave out the derived from relationship",
+	)
+	parents, err = model.ParseParents("")
+	assert.NoError(err, "expect no error from parsing no parent")
+	assert.Empty(parents, "should not have any parent")
+	_, err = model.ParseParents("DBS0236123:cloned_from")
+	assert.Error(err, "expect error from unsupported relationship")
+	_, err = model.ParseParents("DBS0236123,DBS0236123:crossed_from")
+	assert.Error(err, "expect error from repeated parent")
+	_, err = model.ParseParents(":crossed_from")
+	assert.Error(err, "expect error from parent without stock id")
+	_, err = model.ParseParents("DBS0236123,none")
+	assert.Error(err, "expect error from removal along with a parent")
+	parents, replace, err := model.ParseParentUpdate(model.NoParent)
+	assert.NoError(err, "expect no error from removing the parents")
+	assert.True(replace, "should replace the parents")
+	assert.Empty(parents, "should replace the parents with none")
+	_, replace, err = model.ParseParentUpdate("")
+	assert.NoError(err, "expect no error from leaving out the parents")
+	assert.False(replace, "should leave the parents unchanged")
+}
+
+func TestStrainWithParents(t *testing.T) {
+	t.Parallel()
+	assert, repo := setUp(t)
+	defer tearDown(repo)
+	pa, err := repo.AddStrain(context.Background(), newTestParentStrain("j@peterman.org"))
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	pb, err := repo.AddStrain(context.Background(), newTestParentStrain("j@peterman.org"))
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	cross, err := addTestChild(repo, fmt.Sprintf(
+		"%s:crossed_from,%s:crossed_from", pa.StockID, pb.StockID,
+	))
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	derived, err := addTestChild(repo, pa.StockID)
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	m, err := repo.GetStrain(context.Background(), cross)
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	assert.ElementsMatch(
+		[]*model.StrainParent{
+			{ID: pa.StockID, Relationship: model.CrossedFrom},
+			{ID: pb.StockID, Relationship: model.CrossedFrom},
+		},
+		m.StrainProperties.Parents,
+		"should have both the parents with their relationships",
+	)
+	assert.Contains(
+		[]string{pa.StockID, pb.StockID}, m.StrainProperties.Parent,
+		"should have the first parent as the parent",
+	)
+	ls, err := repo.ListStrains(context.Background(), &model.ListParams{
+		Limit:  10,
+		Filter: "ANY(parent_relationship)==crossed_from",
+	})
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	assert.Len(ls, 1, "should only list the crossed strain")
+	assert.Equal(cross, ls[0].StockID, "should match the crossed strain")
+	ls, err = repo.ListStrains(context.Background(), &model.ListParams{
+		Limit:  10,
+		Filter: fmt.Sprintf("parent@==%s", pa.StockID),
+	})
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	assert.Len(ls, 2, "should list every child of the parent")
+	dl, err := repo.ListStrainLineage(context.Background(), &model.LineageParams{
+		ID:            pa.StockID,
+		Direction:     model.LineageDescendants,
+		Relationships: []string{model.DerivedFrom},
+		Limit:         10,
+	})
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	assert.Len(dl, 1, "should only follow the derived from parents")
+	assert.Equal(derived, dl[0].Strain.StockID, "should match the derived strain")
+	al, err := repo.ListStrainLineage(context.Background(), &model.LineageParams{
+		ID:        cross,
+		Direction: model.LineageAncestors,
+		Limit:     10,
+	})
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	assert.Len(al, 2, "should list both the parents")
+	assert.Equal(
+		[]string{model.CrossedFrom}, al[0].Relationships,
+		"should give the relationships along the path",
+	)
+	us, err := repo.EditStrain(context.Background(), strainParentUpdate(
+		cross, fmt.Sprintf("%s:selected_from", pb.StockID),
+	), "")
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	assert.Equal(
+		[]*model.StrainParent{{ID: pb.StockID, Relationship: model.SelectedFrom}},
+		us.StrainProperties.Parents,
+		"should replace the parents",
+	)
+	m, err = repo.GetStrain(context.Background(), cross)
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	assert.Len(m.StrainProperties.Parents, 1, "should only have the new parent")
+	assert.Equal(pb.StockID, m.StrainProperties.Parent, "should match the new parent")
+}
+
+func TestRemoveStrainParents(t *testing.T) {
+	t.Parallel()
+	assert, repo := setUp(t)
+	defer tearDown(repo)
+	pa, err := repo.AddStrain(context.Background(), newTestParentStrain("j@peterman.org"))
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	pb, err := repo.AddStrain(context.Background(), newTestParentStrain("j@peterman.org"))
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	child, err := addTestChild(repo, fmt.Sprintf(
+		"%s:crossed_from,%s:crossed_from", pa.StockID, pb.StockID,
+	))
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	_, err = repo.EditStrain(
+		context.Background(), strainParentUpdate(child, ""), "",
+	)
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	m, err := repo.GetStrain(context.Background(), child)
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	assert.Len(m.StrainProperties.Parents, 2, "should leave the parents unchanged")
+	um, err := repo.EditStrain(
+		context.Background(), strainParentUpdate(child, model.NoParent), "",
+	)
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	assert.Empty(um.StrainProperties.Parents, "should not have any parent")
+	assert.Empty(um.StrainProperties.Parent, "should not have any parent")
+	m, err = repo.GetStrain(context.Background(), child)
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	assert.Empty(m.StrainProperties.Parents, "should remove the parent edges")
+	dl, err := repo.ListStrainLineage(context.Background(), &model.LineageParams{
+		ID:        pa.StockID,
+		Direction: model.LineageDescendants,
+		Limit:     10,
+	})
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	assert.Empty(dl, "should not have any descendant")
+	rl, err := repo.ListStockRevisions(context.Background(), child, nil, 10)
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	removals := 0
+	for _, rm := range rl {
+		if rm.Action == model.RevisionUpdate &&
+			len(rm.Before.StrainProperties.Parents) == 2 &&
+			len(rm.After.StrainProperties.Parents) == 0 {
+			removals++
+		}
+	}
+	assert.Equal(1, removals, "should record the removal of the parents")
+}
+
+func TestRemovedParent(t *testing.T) {
+	t.Parallel()
+	assert, repo := setUp(t)
+	defer tearDown(repo)
+	pa, err := repo.AddStrain(context.Background(), newTestParentStrain("j@peterman.org"))
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	pb, err := repo.AddStrain(context.Background(), newTestParentStrain("j@peterman.org"))
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	child, err := addTestChild(repo, fmt.Sprintf(
+		"%s:crossed_from,%s:crossed_from", pa.StockID, pb.StockID,
+	))
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	err = repo.RemoveStock(context.Background(), pa.StockID, "art@vandelay.com")
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	m, err := repo.GetStrain(context.Background(), child)
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	assert.Equal(
+		[]*model.StrainParent{{ID: pb.StockID, Relationship: model.CrossedFrom}},
+		m.StrainProperties.Parents,
+		"should leave out the removed parent",
+	)
+	assert.Equal(pb.StockID, m.StrainProperties.Parent, "should not give the removed parent")
+	err = repo.RemoveStock(context.Background(), pb.StockID, "art@vandelay.com")
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	var keys []string
+	var cursor *model.StockCursor
+	for {
+		dl, err := repo.ListDeletedStocks(context.Background(), cursor, 1)
+		assert.NoErrorf(err, "expect no error, received %s", err)
+		keys = append(keys, dl[0].Key)
+		if len(dl) == 1 {
+			break
+		}
+		cursor = &model.StockCursor{Values: dl[0].SortValues, Key: dl[0].Key}
+	}
+	assert.ElementsMatch(
+		[]string{pa.StockID, pb.StockID}, keys,
+		"should page through every removed stock once",
+	)
+	err = repo.RestoreStock(context.Background(), pa.StockID, "art@vandelay.com")
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	m, err = repo.GetStrain(context.Background(), child)
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	assert.Equal(pa.StockID, m.StrainProperties.Parent, "should give the restored parent")
+}
+
+func TestFindCycles(t *testing.T) {
+	t.Parallel()
+	assert := require.New(t)
+	assert.Empty(
+		findCycles([]*parentEdge{
+			{Parent: "A", Child: "B"},
+			{Parent: "A", Child: "C"},
+			{Parent: "B", Child: "D"},
+			{Parent: "C", Child: "D"},
+		}),
+		"should not find any cycle when strains share a descendant",
+	)
+	assert.Equal(
+		[][]string{{"A", "B", "C", "A"}, {"D", "D"}},
+		findCycles([]*parentEdge{
+			{Parent: "C", Child: "A"},
+			{Parent: "A", Child: "B"},
+			{Parent: "B", Child: "C"},
+			{Parent: "C", Child: "E"},
+			{Parent: "D", Child: "D"},
+		}),
+		"should find the cycle of parents and the strain that is its own parent",
+	)
+}
+
+func TestParentCycle(t *testing.T) {
+	t.Parallel()
+	assert, repo := setUp(t)
+	defer tearDown(repo)
+	root, err := repo.AddStrain(context.Background(), newTestParentStrain("j@peterman.org"))
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	child, err := addTestChild(repo, root.StockID)
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	grandchild, err := addTestChild(repo, child)
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	_, err = repo.EditStrain(
+		context.Background(), strainParentUpdate(child, child), "",
+	)
+	assert.True(
+		errors.Is(err, repository.ErrParentCycle),
+		"expect cycle error from strain that is its own parent",
+	)
+	_, err = repo.EditStrain(
+		context.Background(), strainParentUpdate(root.StockID, grandchild), "",
+	)
+	assert.True(
+		errors.Is(err, repository.ErrParentCycle),
+		"expect cycle error from parent that is a descendant",
+	)
+	assert.Contains(
+		err.Error(),
+		fmt.Sprintf("%s -> %s -> %s -> %s", root.StockID, child, grandchild, root.StockID),
+		"should name the path of the cycle",
+	)
+	m, err := repo.GetStrain(context.Background(), root.StockID)
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	assert.Empty(m.StrainProperties.Parents, "should leave the parents unchanged")
+	cycles, err := repo.ListParentCycles(context.Background())
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	assert.Empty(cycles, "should not find any cycle")
+}
+
+func TestConcurrentParentCycle(t *testing.T) {
+	t.Parallel()
+	assert, repo := setUp(t)
+	defer tearDown(repo)
+	sa, err := repo.AddStrain(context.Background(), newTestParentStrain("j@peterman.org"))
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	sb, err := repo.AddStrain(context.Background(), newTestParentStrain("j@peterman.org"))
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	edits := []*stock.StrainUpdate{
+		strainParentUpdate(sa.StockID, sb.StockID),
+		strainParentUpdate(sb.StockID, sa.StockID),
+	}
+	errs := make([]error, len(edits))
+	var wg sync.WaitGroup
+	for i, us := range edits {
+		wg.Add(1)
+		go func(i int, us *stock.StrainUpdate) {
+			defer wg.Done()
+			_, errs[i] = repo.EditStrain(context.Background(), us, "")
+		}(i, us)
+	}
+	wg.Wait()
+	refused := 0
+	for _, err := range errs {
+		if errors.Is(err, repository.ErrParentCycle) {
+			refused++
+			continue
+		}
+		assert.NoErrorf(err, "expect no error, received %s", err)
+	}
+	assert.Equal(1, refused, "should refuse one of the edits as a cycle")
+	cycles, err := repo.ListParentCycles(context.Background())
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	assert.Empty(cycles, "should not find any cycle")
+}
+
+// strainParentUpdate only updates the parents of a strain
+func strainParentUpdate(id, parent string) *stock.StrainUpdate {
+	return &stock.StrainUpdate{
+		Data: &stock.StrainUpdate_Data{
+			Type: "strain",
+			Id:   id,
+			Attributes: &stock.StrainUpdateAttributes{
+				UpdatedBy: "kirby@snes.org",
+				Parent:    parent,
+			},
+		},
+	}
+}
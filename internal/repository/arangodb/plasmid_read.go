@@ -1,98 +1,81 @@
 package arangodb
 
 import (
+	"context"
 	"fmt"
 
-	"github.com/dictyBase/go-genproto/dictybaseapis/stock"
 	"github.com/dictyBase/modware-stock/internal/model"
 	"github.com/dictyBase/modware-stock/internal/repository/arangodb/statement"
 )
 
 // ListPlasmids provides a list of all plasmids
 func (ar *arangorepository) ListPlasmids(
-	p *stock.StockParameters,
+	ctx context.Context,
+	p *model.ListParams,
 ) ([]*model.StockDoc, error) {
-	var om []*model.StockDoc
-	stmt := ar.plasmidStmtNoFilter(p)
-	// if filter string exists, it needs to be included in statement
-	if len(p.Filter) > 0 {
-		stmt = ar.plasmidStmtWithFilter(p)
+	filter, filterVars, err := filterClause(p.Filter, plasmidPage)
+	if err != nil {
+		return []*model.StockDoc{}, err
 	}
-	rs, err := ar.database.Search(stmt)
+	bindVars := map[string]interface{}{
+		"@stock_collection": ar.stockc.stock.Name(),
+		"stock_prop_graph":  ar.stockc.stockPropType.Name(),
+		"limit":             p.Limit + 1,
+	}
+	page, err := plasmidPage.clause(p, bindVars)
 	if err != nil {
-		return om, err
+		return []*model.StockDoc{}, err
 	}
-	if rs.IsEmpty() {
-		return om, nil
+	proj, err := ar.project(plasmidProjection, p.Fields, true, bindVars)
+	if err != nil {
+		return []*model.StockDoc{}, err
 	}
-	for rs.Scan() {
-		m := &model.StockDoc{}
-		if err := rs.Read(m); err != nil {
-			return om, err
-		}
-		om = append(om, m)
+	stmt := fmt.Sprintf(statement.PlasmidList, page, proj)
+	// if filter string exists, it needs to be included in statement
+	if len(filter) > 0 {
+		stmt = fmt.Sprintf(statement.PlasmidListFilter, filter, page, proj)
+		mergeBindVars(bindVars, filterVars)
 	}
-	return om, nil
+	return searchRows[model.StockDoc](ar.directTx(ctx), stmt, bindVars)
 }
 
-// GetPlasmid retrieves a plasmid from the database
-func (ar *arangorepository) GetPlasmid(id string) (*model.StockDoc, error) {
+// GetPlasmid retrieves a plasmid from the database, limited to the
+// selected fields if there are any
+func (ar *arangorepository) GetPlasmid(
+	ctx context.Context,
+	id string,
+	fields ...string,
+) (*model.StockDoc, error) {
 	m := &model.StockDoc{}
-	r, err := ar.database.GetRow(
-		statement.StockGetPlasmid,
-		map[string]interface{}{
-			"id":                id,
-			"@stock_collection": ar.stockc.stock.Name(),
-			"stock_prop_graph":  ar.stockc.stockPropType.Name(),
-		})
+	bindVars := map[string]interface{}{
+		"id":                id,
+		"@stock_collection": ar.stockc.stock.Name(),
+		"stock_prop_graph":  ar.stockc.stockPropType.Name(),
+	}
+	proj, err := ar.project(plasmidProjection, fields, false, bindVars)
 	if err != nil {
 		return m, err
 	}
-	if r.IsEmpty() {
-		m.NotFound = true
-		return m, nil
+	found, err := ar.directTx(ctx).getRow(
+		fmt.Sprintf(statement.StockGetPlasmid, proj), bindVars, m,
+	)
+	if err != nil {
+		return m, err
 	}
-	err = r.Read(m)
-	return m, err
-}
-
-func (ar *arangorepository) plasmidStmtWithFilter(
-	p *stock.StockParameters,
-) string {
-	if p.Cursor == 0 { // no cursor so return first set of result
-		return fmt.Sprintf(
-			statement.PlasmidListFilter,
-			ar.stockc.stock.Name(),
-			ar.stockc.stockPropType.Name(),
-			p.Filter, p.Limit+1,
-		)
+	if !found {
+		m.NotFound = true
 	}
-	// else include both filter and cursor
-	return fmt.Sprintf(
-		statement.PlasmidListFilterWithCursor,
-		ar.stockc.stock.Name(),
-		ar.stockc.stockPropType.Name(),
-		p.Filter, p.Cursor, p.Limit+1,
-	)
+	return m, nil
 }
 
-func (ar *arangorepository) plasmidStmtNoFilter(
-	p *stock.StockParameters,
-) string {
-	// otherwise use query statement without filter
-	if p.Cursor == 0 { // no cursor so return first set of result
-		return fmt.Sprintf(
-			statement.PlasmidList,
-			ar.stockc.stock.Name(),
-			ar.stockc.stockPropType.Name(),
-			p.Limit+1,
-		)
+// CountPlasmids counts all the plasmids that match the filter
+func (ar *arangorepository) CountPlasmids(
+	ctx context.Context,
+	filter string,
+) (int64, error) {
+	clause, bindVars, err := filterClause(filter, plasmidPage)
+	if err != nil {
+		return 0, err
 	}
-	// add cursor if it exists
-	return fmt.Sprintf(
-		statement.PlasmidListWithCursor,
-		ar.stockc.stock.Name(),
-		ar.stockc.stockPropType.Name(),
-		p.Cursor, p.Limit+1,
-	)
+	return ar.countStocks(ctx, "plasmid", clause, bindVars)
 }
@@ -1,6 +1,7 @@
 package arangodb
 
 import (
+	"context"
 	"fmt"
 	"regexp"
 	"testing"
@@ -15,16 +16,13 @@ import (
 )
 
 const (
-	georgeFilter = `FILTER s.depositor == 'george@costanza.com'`
-	pfilterTwo   = `FILTER s.depositor == 'george@costanza.com' AND s.depositor == 'rg@gmail.com'`
-	pfilterThree = `LET x = (
-				FILTER '1348970' IN s.publications 
-				RETURN 1
-			)`
-	pfilterFour  = `FILTER s.created_at <= DATE_ISO8601('2019')`
-	pfilterFive  = `FILTER stock_prop.sequence =~ 'ttttt'`
-	pfilterSix   = `FILTER s.summary =~ 'test'`
-	pfilterSeven = `FILTER s.depositor == 'george@costanza.com' OR stock_prop.name == 'gammaS13'`
+	georgeFilter = "depositor==george@costanza.com"
+	pfilterTwo   = "depositor==george@costanza.com;depositor==rg@gmail.com"
+	pfilterThree = "plasmid_name==p123456"
+	pfilterFour  = "created_at$<=2019"
+	pfilterFive  = "plasmid_name=~p123"
+	pfilterSix   = "summary=~test"
+	pfilterSeven = "depositor==george@costanza.com,plasmid_name==gammaS13"
 )
 
 func TestLoadStockWithPlasmids(t *testing.T) {
@@ -50,7 +48,7 @@ func TestLoadStockWithPlasmids(t *testing.T) {
 			},
 		},
 	}
-	um, err := repo.LoadPlasmid("DBP0000098", ns)
+	um, err := repo.LoadPlasmid(context.Background(), "DBP0000098", ns)
 	assert.NoErrorf(err, "expect no error, received %s", err)
 	assert.Equal(
 		"DBP0000098",
@@ -106,11 +104,12 @@ func TestListPlasmidsWithFilter(t *testing.T) {
 		np := newTestPlasmid(
 			fmt.Sprintf("%s@cye.com", arangomanager.RandomString(15, 25)),
 		)
-		_, err := repo.AddPlasmid(np)
+		_, err := repo.AddPlasmid(context.Background(), np)
 		assert.NoErrorf(err, "expect no error, received %s", err)
 	}
 	sf, err := repo.ListPlasmids(
-		&stock.StockParameters{Limit: 10, Filter: georgeFilter},
+		context.Background(),
+		&model.ListParams{Limit: 10, Filter: georgeFilter},
 	)
 	assert.NoErrorf(err, "expect no error, received %s", err)
 	assert.Len(sf, 10, "should list ten plasmids")
@@ -123,14 +122,16 @@ func TestListPlasmidsWithFilter(t *testing.T) {
 		assert.Equal(um.PlasmidProperties.Name, "p123456", "should match name")
 	}
 	n, err := repo.ListPlasmids(
-		&stock.StockParameters{Limit: 100, Filter: pfilterTwo},
+		context.Background(),
+		&model.ListParams{Limit: 100, Filter: pfilterTwo},
 	)
 	assert.NoErrorf(err, "expect no error, received %s", err)
 	assert.Len(n, 0, "should list no plasmids")
 	// do a check for array filter
 	as, err := repo.ListPlasmids(
-		&stock.StockParameters{
-			Cursor: toTimestamp(sf[5].CreatedAt),
+		context.Background(),
+		&model.ListParams{
+			Cursor: createdCursor(sf[5].CreatedAt),
 			Limit:  10,
 			Filter: pfilterThree,
 		},
@@ -138,8 +139,9 @@ func TestListPlasmidsWithFilter(t *testing.T) {
 	assert.NoErrorf(err, "expect no error, received %s", err)
 	assert.Len(as, 5, "should list five plasmids")
 	da, err := repo.ListPlasmids(
-		&stock.StockParameters{
-			Cursor: toTimestamp(sf[5].CreatedAt),
+		context.Background(),
+		&model.ListParams{
+			Cursor: createdCursor(sf[5].CreatedAt),
 			Limit:  10,
 			Filter: pfilterFour,
 		},
@@ -147,18 +149,21 @@ func TestListPlasmidsWithFilter(t *testing.T) {
 	assert.NoErrorf(err, "expect no error, received %s", err)
 	assert.Len(da, 0, "should list no plasmids")
 	ff, err := repo.ListPlasmids(
-		&stock.StockParameters{Limit: 10, Filter: pfilterFive},
+		context.Background(),
+		&model.ListParams{Limit: 10, Filter: pfilterFive},
 	)
 	assert.NoErrorf(err, "expect no error, received %s", err)
 	assert.Len(ff, 10, "should list ten plasmids")
 	fs, err := repo.ListPlasmids(
-		&stock.StockParameters{Limit: 10, Filter: pfilterSix},
+		context.Background(),
+		&model.ListParams{Limit: 10, Filter: pfilterSix},
 	)
 	assert.NoErrorf(err, "expect no error, received %s", err)
 	assert.Len(fs, 10, "should list ten plasmids")
 	fv, err := repo.ListPlasmids(
-		&stock.StockParameters{
-			Cursor: toTimestamp(sf[5].CreatedAt),
+		context.Background(),
+		&model.ListParams{
+			Cursor: createdCursor(sf[5].CreatedAt),
 			Limit:  10,
 			Filter: pfilterSeven,
 		},
@@ -174,10 +179,10 @@ func TestListPlasmids(t *testing.T) {
 		np := newTestPlasmid(
 			fmt.Sprintf("%s@cye.com", arangomanager.RandomString(15, 20)),
 		)
-		_, err := repo.AddPlasmid(np)
+		_, err := repo.AddPlasmid(context.Background(), np)
 		assert.NoErrorf(err, "expect no error adding plasmid, received %s", err)
 	}
-	ls, err := repo.ListPlasmids(&stock.StockParameters{Limit: 4})
+	ls, err := repo.ListPlasmids(context.Background(), &model.ListParams{Limit: 4})
 	assert.NoErrorf(
 		err,
 		"expect no error getting first five plasmids, received %s",
@@ -214,8 +219,8 @@ func testMoreListPlasmids(
 	// convert fifth result to numeric timestamp in milliseconds
 	// so we can use this as cursor
 	// get next five results (5-9)
-	ti := toTimestamp(ls[len(ls)-1].CreatedAt)
-	ls2, err := repo.ListPlasmids(&stock.StockParameters{Cursor: ti, Limit: 4})
+	ti := createdCursor(ls[len(ls)-1].CreatedAt)
+	ls2, err := repo.ListPlasmids(context.Background(), &model.ListParams{Cursor: ti, Limit: 4})
 	assert.NoErrorf(
 		err,
 		"expect no error getting plasmids 5-9, received %s",
@@ -234,9 +239,9 @@ func testMoreListPlasmids(
 	)
 
 	// convert ninth result to numeric timestamp
-	ti2 := toTimestamp(ls2[len(ls2)-1].CreatedAt)
+	ti2 := createdCursor(ls2[len(ls2)-1].CreatedAt)
 	// get last results (9-10)
-	ls3, err := repo.ListPlasmids(&stock.StockParameters{Cursor: ti2, Limit: 4})
+	ls3, err := repo.ListPlasmids(context.Background(), &model.ListParams{Cursor: ti2, Limit: 4})
 	assert.NoErrorf(
 		err,
 		"expect no error getting plasmids 9-10, received %s",
@@ -254,7 +259,8 @@ func testMoreListPlasmids(
 	testModelListSort(ls3, t)
 
 	sf, err := repo.ListPlasmids(
-		&stock.StockParameters{Limit: 100, Filter: georgeFilter},
+		context.Background(),
+		&model.ListParams{Limit: 100, Filter: georgeFilter},
 	)
 	assert.NoErrorf(
 		err,
@@ -264,7 +270,8 @@ func testMoreListPlasmids(
 	assert.Len(sf, 10, "should list ten plasmids")
 
 	cs, err := repo.ListPlasmids(
-		&stock.StockParameters{Cursor: toTimestamp(sf[4].CreatedAt), Limit: 10},
+		context.Background(),
+		&model.ListParams{Cursor: createdCursor(sf[4].CreatedAt), Limit: 10},
 	)
 	assert.NoErrorf(
 		err,
@@ -278,9 +285,9 @@ func TestGetPlasmid(t *testing.T) {
 	assert, repo := setUp(t)
 	defer tearDown(repo)
 	ns := newTestPlasmid("george@costanza.com")
-	um, err := repo.AddPlasmid(ns)
+	um, err := repo.AddPlasmid(context.Background(), ns)
 	assert.NoErrorf(err, "expect no error, received %s", err)
-	g, err := repo.GetPlasmid(um.StockID)
+	g, err := repo.GetPlasmid(context.Background(), um.StockID)
 	assert.NoErrorf(err, "expect no error, received %s", err)
 	assert.Regexp(
 		regexp.MustCompile(`^DBP0\d{6,}$`),
@@ -332,7 +339,7 @@ func TestGetPlasmid(t *testing.T) {
 		"should match updated time of stock",
 	)
 
-	ne, err := repo.GetPlasmid("DBP01")
+	ne, err := repo.GetPlasmid(context.Background(), "DBP01")
 	assert.NoErrorf(err, "expect no error, received %s", err)
 	assert.True(ne.NotFound, "entry should not exist")
 }
@@ -341,7 +348,7 @@ func TestEditPlasmid(t *testing.T) {
 	assert, repo := setUp(t)
 	defer tearDown(repo)
 	ns := newUpdatableTestPlasmid("art@vandelay.org")
-	m, err := repo.AddPlasmid(ns)
+	m, err := repo.AddPlasmid(context.Background(), ns)
 	assert.NoErrorf(err, "expect no error, received %s", err)
 	us := &stock.PlasmidUpdate{
 		Data: &stock.PlasmidUpdate_Data{
@@ -357,7 +364,7 @@ func TestEditPlasmid(t *testing.T) {
 			},
 		},
 	}
-	um, err := repo.EditPlasmid(us)
+	um, err := repo.EditPlasmid(context.Background(), us, "")
 	assert.NoErrorf(err, "expect no error, received %s", err)
 	assert.Equal(um.StockID, um.StockID, "should match the stock id")
 	assert.Equal(
@@ -427,10 +434,10 @@ func TestEditPlasmidGene(t *testing.T) {
 	assert, repo := setUp(t)
 	defer tearDown(repo)
 	ns := newUpdatableTestPlasmid("art@vandelay.org")
-	um, err := repo.AddPlasmid(ns)
+	um, err := repo.AddPlasmid(context.Background(), ns)
 	assert.NoErrorf(err, "expect no error, received %s", err)
 	us2 := PlasmidUpdateInstance(um, ns)
-	um2, err := repo.EditPlasmid(us2)
+	um2, err := repo.EditPlasmid(context.Background(), us2, "")
 	assert.NoErrorf(err, "expect no error, received %s", err)
 	assert.Equal(um2.StockID, um.StockID, "should match the previous stock id")
 	assert.Equal(
@@ -474,7 +481,7 @@ func TestEditPlasmidGene(t *testing.T) {
 			},
 		},
 	}
-	um3, err := repo.EditPlasmid(us3)
+	um3, err := repo.EditPlasmid(context.Background(), us3, "")
 	assert.NoErrorf(err, "expect no error, received %s", err)
 	assert.Equal(um3.StockID, um.StockID, "should match the original stock id")
 	assert.Equal(
@@ -503,7 +510,7 @@ func TestAddPlasmid(t *testing.T) {
 	assert, repo := setUp(t)
 	defer tearDown(repo)
 	ns := newTestPlasmid("george@costanza.com")
-	um, err := repo.AddPlasmid(ns)
+	um, err := repo.AddPlasmid(context.Background(), ns)
 	assert.NoErrorf(err, "expect no error, received %s", err)
 	assert.Regexp(
 		regexp.MustCompile(`^DBP0\d{6,}$`),
@@ -555,3 +562,31 @@ func TestAddPlasmid(t *testing.T) {
 		"should match name",
 	)
 }
+
+func TestCountPlasmids(t *testing.T) {
+	assert, repo := setUp(t)
+	defer tearDown(repo)
+	for i := 1; i <= 6; i++ {
+		_, err := repo.AddPlasmid(
+			context.Background(),
+			newTestPlasmid(
+				fmt.Sprintf("%s@cye.com", arangomanager.RandomString(15, 25)),
+			),
+		)
+		assert.NoErrorf(err, "expect no error, received %s", err)
+	}
+	_, err := repo.AddStrain(
+		context.Background(),
+		newTestStrain("todd@gagg.com", General),
+	)
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	n, err := repo.CountPlasmids(context.Background(), "")
+	assert.NoError(err, "expect no error from counting plasmids")
+	assert.Equal(int64(6), n, "should count only plasmids")
+	n, err = repo.CountPlasmids(context.Background(), pfilterThree)
+	assert.NoError(err, "expect no error from counting with filter")
+	assert.Equal(int64(6), n, "should count plasmids matching name")
+	n, err = repo.CountPlasmids(context.Background(), pfilterTwo)
+	assert.NoError(err, "expect no error from counting with filter")
+	assert.Equal(int64(0), n, "should count no plasmids")
+}
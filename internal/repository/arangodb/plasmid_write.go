@@ -1,6 +1,7 @@
 package arangodb
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/dictyBase/go-genproto/dictybaseapis/stock"
@@ -11,73 +12,103 @@ import (
 // LoadPlasmid will insert existing plasmid data into the database.
 // It receives the already existing plasmid ID and the data to go with it.
 func (ar *arangorepository) LoadPlasmid(
+	ctx context.Context,
 	id string,
 	ep *stock.ExistingPlasmid,
 ) (*model.StockDoc, error) {
-	m := &model.StockDoc{}
 	bindVars := mergeBindParams(map[string]interface{}{
 		"stock_id":                     id,
 		"@stock_collection":            ar.stockc.stock.Name(),
 		"@stock_type_collection":       ar.stockc.stockType.Name(),
 		"@stock_properties_collection": ar.stockc.stockProp.Name(),
 	}, existingPlasmidBindParams(ep.Data.Attributes))
-	r, err := ar.database.DoRun(statement.StockPlasmidLoad, bindVars)
-	if err != nil {
-		return m, err
-	}
-	err = r.Read(m)
-	return m, err
+	return ar.persistPlasmid(
+		ctx,
+		statement.StockPlasmidLoad, bindVars,
+		model.RevisionLoad, ep.Data.Attributes.CreatedBy,
+	)
 }
 
-// EditPlasmid updates an existing plasmid
+// EditPlasmid updates an existing plasmid. A non-empty rev is the combined
+// revision the plasmid is expected to be at, as in EditStrain.
 func (ar *arangorepository) EditPlasmid(
+	ctx context.Context,
 	us *stock.PlasmidUpdate,
+	rev string,
 ) (*model.StockDoc, error) {
 	m := &model.StockDoc{}
-	propKey, err := ar.checkStock(us.Data.Id)
-	if err != nil {
-		return m, err
-	}
-	bindVars := getUpdatablePlasmidBindParams(us.Data.Attributes)
-	bindPlVars := getUpdatablePlasmidPropBindParams(us.Data.Attributes)
-	cmBindVars := mergeBindParams(
-		map[string]interface{}{
-			"@stock_properties_collection": ar.stockc.stockProp.Name(),
-			"@stock_collection":            ar.stockc.stock.Name(),
-			"key":                          us.Data.Id,
-			"propkey":                      propKey,
-		},
-		bindVars, bindPlVars,
-	)
-	rupd, err := ar.database.DoRun(
-		fmt.Sprintf(
-			statement.PlasmidUpd,
-			genAQLDocExpression(bindVars),
-			genAQLDocExpression(bindPlVars),
-		), cmBindVars)
-	if err != nil {
-		return m, err
-	}
-	err = rupd.Read(m)
+	err := ar.withTransaction(ctx, func(tx *dbTx) error {
+		propKey, err := ar.checkStock(tx, us.Data.Id)
+		if err != nil {
+			return err
+		}
+		rVars, err := ar.revisionBindParams(tx, us.Data.Id, propKey, rev)
+		if err != nil {
+			return err
+		}
+		before, err := ar.stockSnapshot(tx, us.Data.Id)
+		if err != nil {
+			return err
+		}
+		bindVars := getUpdatablePlasmidBindParams(us.Data.Attributes)
+		bindPlVars := getUpdatablePlasmidPropBindParams(us.Data.Attributes)
+		cmBindVars := mergeBindParams(
+			map[string]interface{}{
+				"@stock_properties_collection": ar.stockc.stockProp.Name(),
+				"@stock_collection":            ar.stockc.stock.Name(),
+				"key":                          us.Data.Id,
+				"propkey":                      propKey,
+			},
+			bindVars, bindPlVars, rVars,
+		)
+		_, err = tx.getRow(
+			fmt.Sprintf(
+				statement.PlasmidUpd,
+				genAQLDocExpression(bindVars),
+				genAQLDocExpression(bindPlVars),
+			), cmBindVars, m)
+		if err != nil {
+			return updateError(us.Data.Id, rev, err)
+		}
+		return ar.addRevision(
+			tx, us.Data.Id, model.RevisionUpdate,
+			us.Data.Attributes.UpdatedBy, before,
+		)
+	})
 	return m, err
 }
 
 // AddPlasmid creates a new plasmid stock
 func (ar *arangorepository) AddPlasmid(
+	ctx context.Context,
 	ns *stock.NewPlasmid,
 ) (*model.StockDoc, error) {
-	m := &model.StockDoc{}
 	bindVars := mergeBindParams(map[string]interface{}{
 		"@stock_collection":            ar.stockc.stock.Name(),
 		"@stock_key_generator":         ar.stockc.stockKey.Name(),
 		"@stock_type_collection":       ar.stockc.stockType.Name(),
 		"@stock_properties_collection": ar.stockc.stockProp.Name(),
 	}, addablePlasmidBindParams(ns.Data.Attributes))
-	r, err := ar.database.DoRun(statement.StockPlasmidIns, bindVars)
-	if err != nil {
-		return m, err
-	}
-	err = r.Read(m)
+	return ar.persistPlasmid(
+		ctx,
+		statement.StockPlasmidIns, bindVars,
+		model.RevisionCreate, ns.Data.Attributes.CreatedBy,
+	)
+}
+
+func (ar *arangorepository) persistPlasmid(
+	ctx context.Context,
+	stmt string,
+	bindVars map[string]interface{},
+	action, actor string,
+) (*model.StockDoc, error) {
+	m := &model.StockDoc{}
+	err := ar.withTransaction(ctx, func(tx *dbTx) error {
+		if _, err := tx.getRow(stmt, bindVars, m); err != nil {
+			return err
+		}
+		return ar.addRevision(tx, m.Key, action, actor, nil)
+	})
 	return m, err
 }
 
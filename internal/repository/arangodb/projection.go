@@ -0,0 +1,187 @@
+package arangodb
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"github.com/dictyBase/modware-stock/internal/repository"
+	"github.com/dictyBase/modware-stock/internal/repository/arangodb/statement"
+)
+
+const (
+	termExpr = `FIRST(
+		FOR cg IN 1..1 OUTBOUND s GRAPH @stock_cvterm_graph
+			FOR tcv IN @@cv_collection
+				FILTER cg.deprecated == false
+				FILTER cg.graph_id == tcv._id
+				FILTER tcv.metadata.namespace == @ontology
+				RETURN cg.label
+	)`
+)
+
+// identityAttributes are always projected, as the responses, the cursors
+// and the revision headers are made from them
+var identityAttributes = []string{
+	"_key: s._key",
+	"_id: s._id",
+	"_rev: s._rev",
+	"stock_id: s.stock_id",
+	"prop_rev: stock_prop._rev",
+}
+
+// attribute is a field of a stock response that could be selected
+type attribute struct {
+	// expr gives the value of the attribute in the RETURN projection
+	expr string
+	// prop places the attribute within the properties of the stock
+	prop bool
+	// vars are the bind parameters of a traversed attribute, the lists
+	// leave such attributes out unless they are selected
+	vars []string
+}
+
+var commonAttributes = map[string]attribute{
+	"created_at":       {expr: "s.created_at"},
+	"updated_at":       {expr: "s.updated_at"},
+	"created_by":       {expr: "s.created_by"},
+	"updated_by":       {expr: "s.updated_by"},
+	"summary":          {expr: "s.summary"},
+	"editable_summary": {expr: "s.editable_summary"},
+	"depositor":        {expr: "s.depositor"},
+	"genes":            {expr: "s.genes"},
+	"dbxrefs":          {expr: "s.dbxrefs"},
+	"publications":     {expr: "s.publications"},
+}
+
+// stockProjection gives the attributes of a stock type that a response
+// could be limited to
+type stockProjection struct {
+	attrs map[string]attribute
+	// props is the name of the attribute that holds the stock properties
+	props string
+}
+
+var (
+	strainProjection = stockProjection{
+		attrs: withCommonAttributes(map[string]attribute{
+			"label":   {expr: "stock_prop.label", prop: true},
+			"species": {expr: "stock_prop.species", prop: true},
+			"plasmid": {expr: "stock_prop.plasmid", prop: true},
+			"names":   {expr: "stock_prop.names", prop: true},
+			"parent": {
+				expr: "FIRST(" + statement.StrainParents + ").id",
+				prop: true,
+				vars: []string{"parent_graph"},
+			},
+			"parents": {
+				expr: statement.StrainParents,
+				prop: true,
+				vars: []string{"parent_graph"},
+			},
+			"dicty_strain_property": {
+				expr: termExpr,
+				prop: true,
+				vars: []string{"stock_cvterm_graph", "@cv_collection", "ontology"},
+			},
+		}),
+		props: "strain_properties",
+	}
+	plasmidProjection = stockProjection{
+		attrs: withCommonAttributes(map[string]attribute{
+			"image_map": {expr: "stock_prop.image_map", prop: true},
+			"sequence":  {expr: "stock_prop.sequence", prop: true},
+			"name":      {expr: "stock_prop.name", prop: true},
+		}),
+		props: "plasmid_properties",
+	}
+)
+
+func withCommonAttributes(attrs map[string]attribute) map[string]attribute {
+	for name, a := range commonAttributes {
+		attrs[name] = a
+	}
+	return attrs
+}
+
+// selection gives the sorted names of the selected attributes. Without any
+// field, all the attributes are selected, except the traversed ones of a
+// list.
+func (sp stockProjection) selection(fields []string, list bool) ([]string, error) {
+	selected := make(map[string]bool)
+	for _, f := range fields {
+		if _, ok := sp.attrs[f]; !ok {
+			names := make([]string, 0, len(sp.attrs))
+			for name := range sp.attrs {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			return nil, errors.Wrapf(
+				repository.ErrInvalidField,
+				"unsupported field %s, valid fields are %s",
+				f, strings.Join(names, ", "),
+			)
+		}
+		selected[f] = true
+	}
+	if len(fields) == 0 {
+		for name, a := range sp.attrs {
+			selected[name] = !list || len(a.vars) == 0
+		}
+	}
+	// the numeric cursor of a list is the time of creation
+	if list {
+		selected["created_at"] = true
+	}
+	names := make([]string, 0, len(selected))
+	for name, ok := range selected {
+		if ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// project gives the RETURN projection of a stock limited to the selected
+// fields, so that the attributes that are not selected are never read.
+// The projection of a list also has the sort values of the stock. The bind
+// parameters of the traversed attributes are added to bindVars.
+func (ar *arangorepository) project(
+	sp stockProjection,
+	fields []string,
+	list bool,
+	bindVars map[string]interface{},
+) (string, error) {
+	names, err := sp.selection(fields, list)
+	if err != nil {
+		return "", err
+	}
+	values := map[string]interface{}{
+		"parent_graph":       ar.stockc.strain2Parent.Name(),
+		"stock_cvterm_graph": ar.stockc.stockOnto.Name(),
+		"@cv_collection":     ar.ontoc.Cv.Name(),
+		"ontology":           ar.strainOnto,
+	}
+	attrs := append([]string{}, identityAttributes...)
+	var props []string
+	for _, name := range names {
+		a := sp.attrs[name]
+		for _, v := range a.vars {
+			bindVars[v] = values[v]
+		}
+		if a.prop {
+			props = append(props, fmt.Sprintf("%s: %s", name, a.expr))
+			continue
+		}
+		attrs = append(attrs, fmt.Sprintf("%s: %s", name, a.expr))
+	}
+	if list {
+		attrs = append(attrs, "sort_values: sort_values")
+	}
+	attrs = append(attrs, fmt.Sprintf(
+		"%s: { %s }", sp.props, strings.Join(props, ",\n"),
+	))
+	return fmt.Sprintf("{\n%s\n}", strings.Join(attrs, ",\n")), nil
+}
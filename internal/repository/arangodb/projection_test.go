@@ -0,0 +1,92 @@
+package arangodb
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/dictyBase/modware-stock/internal/model"
+	"github.com/dictyBase/modware-stock/internal/repository"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStockSelection(t *testing.T) {
+	t.Parallel()
+	assert := require.New(t)
+	names, err := plasmidProjection.selection([]string{"name", "summary"}, false)
+	assert.NoError(err, "expect no error from selecting plasmid fields")
+	assert.Equal([]string{"name", "summary"}, names, "should only select the fields")
+	names, err = plasmidProjection.selection([]string{"name"}, true)
+	assert.NoError(err, "expect no error from selecting plasmid fields of a list")
+	assert.Equal(
+		[]string{"created_at", "name"}, names,
+		"should select the time of creation for the numeric cursor",
+	)
+	names, err = strainProjection.selection(nil, true)
+	assert.NoError(err, "expect no error from selecting all strain fields")
+	assert.NotContains(names, "parent", "should not traverse the parent of a list")
+	assert.NotContains(
+		names, "dicty_strain_property",
+		"should not traverse the ontology term of a list",
+	)
+	names, err = strainProjection.selection(nil, false)
+	assert.NoError(err, "expect no error from selecting all strain fields")
+	assert.Contains(names, "parent", "should select the parent of a strain")
+	_, err = strainProjection.selection([]string{"sequence"}, false)
+	assert.True(
+		errors.Is(err, repository.ErrInvalidField),
+		"expect invalid field error from plasmid field of a strain",
+	)
+}
+
+func TestListPlasmidsWithFields(t *testing.T) {
+	t.Parallel()
+	assert, repo := setUp(t)
+	defer tearDown(repo)
+	m, err := repo.AddPlasmid(context.Background(), newTestPlasmid("george@costanza.com"))
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	ls, err := repo.ListPlasmids(
+		context.Background(),
+		&model.ListParams{Limit: 10, Fields: []string{"name", "depositor"}},
+	)
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	assert.Len(ls, 1, "should list the plasmid")
+	assert.Equal("p123456", ls[0].PlasmidProperties.Name, "should match the name")
+	assert.Equal("george@costanza.com", ls[0].Depositor, "should match the depositor")
+	assert.Empty(ls[0].PlasmidProperties.Sequence, "should not read the sequence")
+	assert.Empty(ls[0].PlasmidProperties.ImageMap, "should not read the image map")
+	assert.Empty(ls[0].Summary, "should not read the summary")
+	assert.Equal(m.Key, ls[0].Key, "should always have the key")
+	assert.NotEmpty(ls[0].SortValues, "should always have the sort values")
+	gm, err := repo.GetPlasmid(context.Background(), m.Key, "sequence")
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	assert.Equal(
+		"tttttyyyyjkausadaaaavvvvvv", gm.PlasmidProperties.Sequence,
+		"should match the selected sequence",
+	)
+	assert.Empty(gm.PlasmidProperties.Name, "should not read the name")
+	assert.NotEmpty(gm.Revision(), "should always have the revision")
+	sm, err := repo.AddStrain(
+		context.Background(), newTestStrain("george@costanza.com", General),
+	)
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	ss, err := repo.ListStrains(
+		context.Background(),
+		&model.ListParams{Limit: 10, Fields: []string{"label", "dicty_strain_property"}},
+	)
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	assert.Len(ss, 1, "should list the strain")
+	assert.Equal(sm.StrainProperties.Label, ss[0].StrainProperties.Label)
+	assert.Equal(
+		"general strain", ss[0].StrainProperties.DictyStrainProperty,
+		"should traverse the selected ontology term",
+	)
+	_, err = repo.ListPlasmids(
+		context.Background(),
+		&model.ListParams{Limit: 10, Fields: []string{"label"}},
+	)
+	assert.True(
+		errors.Is(err, repository.ErrInvalidField),
+		"expect invalid field error from strain field of a plasmid",
+	)
+}
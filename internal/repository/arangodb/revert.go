@@ -0,0 +1,204 @@
+package arangodb
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	driver "github.com/arangodb/go-driver"
+	"github.com/cockroachdb/errors"
+	"github.com/dictyBase/modware-stock/internal/model"
+	"github.com/dictyBase/modware-stock/internal/repository/arangodb/statement"
+)
+
+// RevertStock resets a stock to its state right after the given revision.
+// The stock and stockprop documents, the parent and the ontology term
+// edges are restored together and the revert is recorded as a new revision.
+// The stock keeps its current removal, which only RestoreStock undoes.
+func (ar *arangorepository) RevertStock(
+	ctx context.Context,
+	id, revision, revertedBy string,
+) (*model.StockDoc, error) {
+	m := &model.StockDoc{}
+	// a revision never changes, so it is read ahead of the transaction to
+	// tell whether the revert adds parent edges
+	target, err := ar.revisionTarget(ar.directTx(ctx), id, revision)
+	if err != nil {
+		return m, err
+	}
+	run := ar.withTransaction
+	if sp := target.StrainProperties; sp != nil && len(sp.ParentList()) > 0 {
+		run = ar.withParentLock
+	}
+	err = run(ctx, func(tx *dbTx) error {
+		propKey, err := ar.checkStock(tx, id)
+		if err != nil {
+			return err
+		}
+		before, err := ar.stockSnapshot(tx, id)
+		if err != nil {
+			return err
+		}
+		bindVars := mergeBindParams(
+			map[string]interface{}{
+				"key":                          id,
+				"propkey":                      propKey,
+				"updated_by":                   revertedBy,
+				"@stock_collection":            ar.stockc.stock.Name(),
+				"@stock_properties_collection": ar.stockc.stockProp.Name(),
+			},
+			revertStockBindParams(target),
+		)
+		var fragments []string
+		if target.StrainProperties != nil {
+			eVars, eStmts, err := ar.revertStrainEdges(
+				tx, id, target.StrainProperties,
+			)
+			if err != nil {
+				return err
+			}
+			bindVars = mergeBindParams(bindVars, eVars)
+			fragments = eStmts
+		}
+		err = tx.do(
+			fmt.Sprintf(statement.StockRevert, strings.Join(fragments, "\n")),
+			bindVars,
+		)
+		if err != nil {
+			return errors.Errorf(
+				"error in reverting stock %s to revision %s %s",
+				id, revision, err,
+			)
+		}
+		if err := ar.addRevision(
+			tx, id, model.RevisionRevert, revertedBy, before,
+		); err != nil {
+			return err
+		}
+		after, err := ar.stockSnapshot(tx, id)
+		if err != nil {
+			return err
+		}
+		m = after
+		return nil
+	})
+	return m, err
+}
+
+func (ar *arangorepository) revisionTarget(
+	tx *dbTx,
+	id, revision string,
+) (*model.StockDoc, error) {
+	rev := &model.StockRevision{}
+	_, err := ar.stockc.stockRevision.ReadDocument(tx.ctx, revision, rev)
+	if err != nil {
+		if driver.IsNotFoundGeneral(err) {
+			return nil, errors.Errorf("revision %s does not exist", revision)
+		}
+		return nil, errors.Errorf(
+			"error in reading revision %s %s",
+			revision, err,
+		)
+	}
+	if rev.StockID != id {
+		return nil, errors.Errorf(
+			"revision %s does not belong to stock %s",
+			revision, id,
+		)
+	}
+	if rev.After == nil {
+		return nil, errors.Errorf(
+			"revision %s has no state to revert to",
+			revision,
+		)
+	}
+	return rev.After, nil
+}
+
+func revertStockBindParams(target *model.StockDoc) map[string]interface{} {
+	bindVars := map[string]interface{}{
+		"summary":          target.Summary,
+		"editable_summary": target.EditableSummary,
+		"depositor":        target.Depositor,
+		"genes":            normalizeSliceBindParam(target.Genes),
+		"dbxrefs":          normalizeSliceBindParam(target.Dbxrefs),
+		"publications":     normalizeSliceBindParam(target.Publications),
+	}
+	if sp := target.StrainProperties; sp != nil {
+		bindVars["stock_prop"] = map[string]interface{}{
+			"label":   sp.Label,
+			"species": sp.Species,
+			"plasmid": sp.Plasmid,
+			"names":   normalizeSliceBindParam(sp.Names),
+		}
+		return bindVars
+	}
+	pp := target.PlasmidProperties
+	bindVars["stock_prop"] = map[string]interface{}{
+		"image_map": pp.ImageMap,
+		"sequence":  pp.Sequence,
+		"name":      pp.Name,
+	}
+	return bindVars
+}
+
+func (ar *arangorepository) revertStrainEdges(
+	tx *dbTx,
+	id string,
+	sp *model.StrainProperties,
+) (map[string]interface{}, []string, error) {
+	bindVars := make(map[string]interface{})
+	var stmts []string
+	// the parent edges are replaced by the ones of the revision
+	parents := sp.ParentList()
+	pVars, err := ar.replaceParents(tx, id, parents)
+	if err != nil {
+		return bindVars, stmts, err
+	}
+	if len(parents) > 0 {
+		bindVars = pVars
+		stmts = append(stmts, statement.RevertParentsIns)
+	}
+	if len(sp.DictyStrainProperty) == 0 {
+		return bindVars, stmts, nil
+	}
+	tKey, err := ar.edgeKey(
+		tx, statement.StockTermRelQ,
+		id, ar.stockc.stockTerm.Name(),
+		"@stock_term_collection",
+	)
+	if err != nil {
+		return bindVars, stmts, err
+	}
+	tid, err := ar.termID(tx, sp.DictyStrainProperty, ar.strainOnto)
+	if err != nil {
+		return bindVars, stmts, err
+	}
+	bindVars["to"] = tid
+	bindVars["@stock_term_collection"] = ar.stockc.stockTerm.Name()
+	// a strain without any term edge gets the one of the revision
+	if len(tKey) == 0 {
+		bindVars["stock_collection"] = ar.stockc.stock.Name()
+		return bindVars, append(stmts, statement.RevertTermIns), nil
+	}
+	bindVars["tkey"] = tKey
+	return bindVars, append(stmts, statement.RevertTermUpd), nil
+}
+
+func (ar *arangorepository) edgeKey(
+	tx *dbTx,
+	stmt, id, coll, collVar string,
+) (string, error) {
+	var key string
+	_, err := tx.getRow(
+		stmt,
+		map[string]interface{}{
+			"key":              id,
+			"stock_collection": ar.stockc.stock.Name(),
+			collVar:            coll,
+		}, &key)
+	if err != nil {
+		return key, errors.Errorf("error in finding edge of %s %s", id, err)
+	}
+	return key, nil
+}
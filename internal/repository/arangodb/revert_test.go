@@ -0,0 +1,62 @@
+package arangodb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dictyBase/modware-stock/internal/model"
+)
+
+func TestRevertStock(t *testing.T) {
+	t.Parallel()
+	assert, repo := setUp(t)
+	defer tearDown(repo)
+	ns := newUpdatableTestStrain("todd@gagg.com", General)
+	m, err := repo.AddStrain(context.Background(), ns)
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	_, err = repo.EditStrain(context.Background(), strainUpdateInstance(ns, m), "")
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	rms, err := repo.ListStockRevisions(context.Background(), m.Key, nil, 10)
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	var crev *model.StockRevision
+	for _, rm := range rms {
+		if rm.Action == model.RevisionCreate {
+			crev = rm
+		}
+	}
+	assert.NotNil(crev, "should have create revision")
+	rm, err := repo.RevertStock(context.Background(), m.Key, crev.Key, "art@vandelay.com")
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	assert.Equal(rm.Summary, ns.Data.Attributes.Summary, "should match summary")
+	assert.Equal(rm.UpdatedBy, "art@vandelay.com", "should match updated_by")
+	assert.Equal(
+		rm.StrainProperties.Label,
+		ns.Data.Attributes.Label,
+		"should match label",
+	)
+	assert.Empty(rm.StrainProperties.Plasmid, "should not have plasmid")
+	assert.Empty(rm.Dbxrefs, "should not have dbxrefs")
+	sm, err := repo.GetStrain(context.Background(), m.Key)
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	assert.Equal(
+		sm.StrainProperties.Label,
+		ns.Data.Attributes.Label,
+		"should match reverted label",
+	)
+	rms, err = repo.ListStockRevisions(context.Background(), m.Key, nil, 10)
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	assert.Len(rms, 3, "should have three revisions")
+	assert.Equal(rms[0].Action, model.RevisionRevert, "should be revert")
+	assert.Equal(rms[0].Actor, "art@vandelay.com", "should match actor")
+	err = repo.RemoveStock(context.Background(), m.Key, "art@vandelay.com")
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	dm, err := repo.RevertStock(context.Background(), m.Key, crev.Key, "art@vandelay.com")
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	assert.NotNil(dm.DeletedAt, "should keep the removal of the stock")
+	_, err = repo.RevertStock(context.Background(), m.Key, "9999999", "art@vandelay.com")
+	assert.Error(err, "should return error for absent revision")
+	pm, err := repo.AddPlasmid(context.Background(), newTestPlasmid("art@vandelay.com"))
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	_, err = repo.RevertStock(context.Background(), pm.Key, crev.Key, "art@vandelay.com")
+	assert.Error(err, "should return error for revision of other stock")
+}
@@ -0,0 +1,217 @@
+package arangodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/dictyBase/modware-stock/internal/model"
+	"github.com/dictyBase/modware-stock/internal/repository/arangodb/statement"
+)
+
+var revisionPage = pageQuery{
+	vars:       map[string]bool{"r": true},
+	key:        "r._key",
+	numericKey: true,
+}
+
+// ListStockRevisions provides the change history of a stock, the most recent
+// revision comes first. The revisions recorded at the same time are ordered
+// by the number of their key, the same order the stock is read as of a
+// time by, so that a page continues after the cursor without repeating or
+// skipping any of them.
+func (ar *arangorepository) ListStockRevisions(
+	ctx context.Context,
+	id string,
+	cursor *model.StockCursor,
+	limit int64,
+) ([]*model.StockRevision, error) {
+	bindVars := map[string]interface{}{
+		"id":                         id,
+		"limit":                      limit + 1,
+		"@stock_revision_collection": ar.stockc.stockRevision.Name(),
+	}
+	page := ""
+	if cursor != nil {
+		cond, err := revisionPage.cursorCondition(
+			cursor,
+			[]string{"sort_values[0]"},
+			[]string{"<"},
+			bindVars,
+		)
+		if err != nil {
+			return []*model.StockRevision{}, err
+		}
+		page = "FILTER " + cond
+	}
+	rms, err := searchRows[model.StockRevision](
+		ar.directTx(ctx),
+		fmt.Sprintf(statement.StockRevisionList, page),
+		bindVars,
+	)
+	if err != nil {
+		return rms, errors.Errorf(
+			"error in listing revisions of stock %s %s",
+			id, err,
+		)
+	}
+	return rms, nil
+}
+
+// BackfillRevisions records a baseline revision of every stock without
+// any, such as the stocks that were there before the revisions were kept,
+// so that they could be read as of a time. Nothing is known of the earlier
+// states of such a stock, so its baseline is its current state as of its
+// last update. It gives the ids of the stocks that got a baseline.
+func (ar *arangorepository) BackfillRevisions(
+	ctx context.Context,
+	actor string,
+) ([]string, error) {
+	ids := make([]string, 0)
+	keys, err := searchRows[string](
+		ar.directTx(ctx),
+		statement.StockUnrevisedQ,
+		map[string]interface{}{
+			"@stock_collection":          ar.stockc.stock.Name(),
+			"@stock_revision_collection": ar.stockc.stockRevision.Name(),
+		})
+	if err != nil {
+		return ids, errors.Errorf("error in finding stocks without revision %s", err)
+	}
+	for _, key := range keys {
+		added, err := ar.addBaseline(ctx, *key, actor)
+		if err != nil {
+			return ids, err
+		}
+		if added {
+			ids = append(ids, *key)
+		}
+	}
+	return ids, nil
+}
+
+// addBaseline records the baseline revision of a stock unless it got a
+// revision or was purged since it was found
+func (ar *arangorepository) addBaseline(
+	ctx context.Context,
+	id, actor string,
+) (bool, error) {
+	added := false
+	err := ar.withTransaction(ctx, func(tx *dbTx) error {
+		var revised bool
+		_, err := tx.getRow(
+			statement.StockRevisedQ,
+			map[string]interface{}{
+				"id":                         id,
+				"@stock_revision_collection": ar.stockc.stockRevision.Name(),
+			}, &revised)
+		if err != nil {
+			return errors.Errorf(
+				"error in finding revision of stock %s %s",
+				id, err,
+			)
+		}
+		if revised {
+			return nil
+		}
+		after, err := ar.stockSnapshot(tx, id)
+		if err != nil || after == nil {
+			return err
+		}
+		err = tx.do(
+			statement.StockBaselineIns,
+			map[string]interface{}{
+				"@stock_revision_collection": ar.stockc.stockRevision.Name(),
+				"created_at":                 baselineTime(after).UnixMilli(),
+				"revision": &model.StockRevision{
+					StockID: id,
+					Action:  model.RevisionBaseline,
+					Actor:   actor,
+					After:   after,
+				},
+			})
+		if err != nil {
+			return errors.Errorf(
+				"error in recording baseline revision of stock %s %s",
+				id, err,
+			)
+		}
+		added = true
+		return nil
+	})
+	return added, err
+}
+
+// baselineTime is the time of the last update of a stock
+func baselineTime(m *model.StockDoc) time.Time {
+	switch {
+	case !m.UpdatedAt.IsZero():
+		return m.UpdatedAt
+	case !m.CreatedAt.IsZero():
+		return m.CreatedAt
+	}
+	return time.Now()
+}
+
+// stockSnapshot retrieves the complete state of a stock, including the
+// removed ones. It returns nil if the stock does not exist.
+func (ar *arangorepository) stockSnapshot(
+	tx *dbTx,
+	id string,
+) (*model.StockDoc, error) {
+	m := &model.StockDoc{}
+	found, err := tx.getRow(
+		statement.StockSnapshotQ,
+		map[string]interface{}{
+			"id":                 id,
+			"ontology":           ar.strainOnto,
+			"parent_graph":       ar.stockc.strain2Parent.Name(),
+			"stock_prop_graph":   ar.stockc.stockPropType.Name(),
+			"stock_cvterm_graph": ar.stockc.stockOnto.Name(),
+			"@stock_collection":  ar.stockc.stock.Name(),
+			"@cv_collection":     ar.ontoc.Cv.Name(),
+		}, m)
+	if err != nil {
+		return nil, errors.Errorf(
+			"error in retrieving snapshot of stock %s %s",
+			id, err,
+		)
+	}
+	if !found {
+		return nil, nil
+	}
+	return m, nil
+}
+
+// addRevision records a change of a stock given its state before the
+// change. The state after the change is retrieved from the database.
+func (ar *arangorepository) addRevision(
+	tx *dbTx,
+	id, action, actor string,
+	before *model.StockDoc,
+) error {
+	after, err := ar.stockSnapshot(tx, id)
+	if err != nil {
+		return err
+	}
+	err = tx.do(
+		statement.StockRevisionIns,
+		map[string]interface{}{
+			"@stock_revision_collection": ar.stockc.stockRevision.Name(),
+			"revision": &model.StockRevision{
+				StockID: id,
+				Action:  action,
+				Actor:   actor,
+				Before:  before,
+				After:   after,
+			},
+		})
+	if err != nil {
+		return errors.Errorf(
+			"error in recording revision of stock %s %s",
+			id, err,
+		)
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package arangodb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dictyBase/modware-stock/internal/model"
+)
+
+func TestListStockRevisions(t *testing.T) {
+	t.Parallel()
+	assert, repo := setUp(t)
+	defer tearDown(repo)
+	ns := newUpdatableTestStrain("todd@gagg.com", General)
+	m, err := repo.AddStrain(context.Background(), ns)
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	us := strainUpdateInstance(ns, m)
+	_, err = repo.EditStrain(context.Background(), us, "")
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	err = repo.RemoveStock(context.Background(), m.Key, "art@vandelay.com")
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	rms, err := repo.ListStockRevisions(context.Background(), m.Key, nil, 10)
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	assert.Len(rms, 3, "should have three revisions")
+	revs := make(map[string]*model.StockRevision)
+	for _, rm := range rms {
+		assert.Equal(rm.StockID, m.Key, "should match the stock id")
+		assert.False(rm.CreatedAt.IsZero(), "should have creation time")
+		revs[rm.Action] = rm
+	}
+	assert.Contains(revs, model.RevisionCreate, "should have create revision")
+	assert.Contains(revs, model.RevisionUpdate, "should have update revision")
+	assert.Contains(revs, model.RevisionDelete, "should have delete revision")
+	crev := revs[model.RevisionCreate]
+	assert.Nil(crev.Before, "create revision should not have prior state")
+	assert.Equal(crev.Actor, ns.Data.Attributes.CreatedBy, "should match actor")
+	assert.Equal(
+		crev.After.StrainProperties.Label,
+		ns.Data.Attributes.Label,
+		"should match created label",
+	)
+	urev := revs[model.RevisionUpdate]
+	assert.Equal(urev.Actor, us.Data.Attributes.UpdatedBy, "should match actor")
+	assert.Equal(
+		urev.Before.Summary,
+		ns.Data.Attributes.Summary,
+		"should match summary before update",
+	)
+	assert.Equal(
+		urev.After.Summary,
+		us.Data.Attributes.Summary,
+		"should match summary after update",
+	)
+	assert.Equal(
+		urev.After.StrainProperties.DictyStrainProperty,
+		"general strain",
+		"should record the strain property term",
+	)
+	drev := revs[model.RevisionDelete]
+	assert.Equal(drev.Actor, "art@vandelay.com", "should match actor")
+	assert.Nil(drev.Before.DeletedAt, "should not be deleted before")
+	assert.NotNil(drev.After.DeletedAt, "should be deleted after")
+	prms, err := repo.ListStockRevisions(context.Background(), m.Key, nil, 1)
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	assert.Len(prms, 2, "should match the provided limit number + 1")
+	var keys []string
+	var cursor *model.StockCursor
+	for {
+		page, err := repo.ListStockRevisions(context.Background(), m.Key, cursor, 1)
+		assert.NoErrorf(err, "expect no error, received %s", err)
+		keys = append(keys, page[0].Key)
+		if len(page) == 1 {
+			break
+		}
+		cursor = &model.StockCursor{Values: page[0].SortValues, Key: page[0].Key}
+	}
+	assert.Equal(
+		[]string{rms[0].Key, rms[1].Key, rms[2].Key}, keys,
+		"should page through every revision once",
+	)
+	nrms, err := repo.ListStockRevisions(context.Background(), "DBS0000000", nil, 10)
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	assert.Empty(nrms, "should not have revisions for absent stock")
+}
+
+func TestPurgeRevision(t *testing.T) {
+	t.Parallel()
+	assert, repo := setUp(t)
+	defer tearDown(repo)
+	m, err := repo.AddPlasmid(context.Background(), newTestPlasmid("george@costanza.com"))
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	err = repo.RemoveStock(context.Background(), m.Key, "art@vandelay.com")
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	err = repo.PurgeStock(context.Background(), m.Key, "art@vandelay.com", false)
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	rms, err := repo.ListStockRevisions(context.Background(), m.Key, nil, 10)
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	assert.Equal(model.RevisionPurge, rms[0].Action, "should have purge revision first")
+	assert.Equal("art@vandelay.com", rms[0].Actor, "should record the purging user")
+}
@@ -0,0 +1,332 @@
+package arangodb
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode"
+
+	driver "github.com/arangodb/go-driver"
+	"github.com/cockroachdb/errors"
+	"github.com/dictyBase/modware-stock/internal/model"
+	"github.com/dictyBase/modware-stock/internal/repository/arangodb/statement"
+)
+
+const (
+	// searchAnalyzer splits the text fields into lower cased and stemmed
+	// english words, it is used both for indexing and for the queries
+	searchAnalyzer = "stock_text"
+	// snippetLength is the largest number of characters of a highlighted
+	// snippet, the longer fields are cut around their first match
+	snippetLength = 160
+	// snippetLead is the number of characters kept before the first
+	// match of a cut snippet
+	snippetLead = 40
+)
+
+var searchPage = pageQuery{
+	vars: map[string]bool{"s": true},
+	key:  "s._key",
+}
+
+// searchAnalyzers are the analyzers of the text search and of the
+// autocompletion
+func searchAnalyzers() []driver.ArangoSearchAnalyzerDefinition {
+	return []driver.ArangoSearchAnalyzerDefinition{
+		searchAnalyzerDef(),
+		normAnalyzerDef(),
+		ngramAnalyzerDef(),
+	}
+}
+
+func searchAnalyzerDef() driver.ArangoSearchAnalyzerDefinition {
+	accent, stemming := false, true
+	return driver.ArangoSearchAnalyzerDefinition{
+		Name: searchAnalyzer,
+		Type: driver.ArangoSearchAnalyzerTypeText,
+		Properties: driver.ArangoSearchAnalyzerProperties{
+			Locale:    "en",
+			Case:      driver.ArangoSearchCaseLower,
+			Accent:    &accent,
+			Stemming:  &stemming,
+			Stopwords: []string{},
+		},
+		Features: []driver.ArangoSearchAnalyzerFeature{
+			driver.ArangoSearchAnalyzerFeatureFrequency,
+			driver.ArangoSearchAnalyzerFeatureNorm,
+			driver.ArangoSearchAnalyzerFeaturePosition,
+		},
+	}
+}
+
+// searchLinks gives the text fields of the stock and stockprop
+// collections that are indexed by the search view
+func searchLinks(ar *arangorepository) driver.ArangoSearchLinks {
+	field := driver.ArangoSearchElementProperties{
+		Analyzers: []string{searchAnalyzer},
+	}
+	// the names are also completed while being typed
+	name := driver.ArangoSearchElementProperties{
+		Analyzers: []string{searchAnalyzer, normAnalyzer, ngramAnalyzer},
+	}
+	return driver.ArangoSearchLinks{
+		ar.stockc.stock.Name(): driver.ArangoSearchElementProperties{
+			Fields: driver.ArangoSearchFields{
+				"summary": field,
+				"genes":   field,
+			},
+		},
+		ar.stockc.stockProp.Name(): driver.ArangoSearchElementProperties{
+			Fields: driver.ArangoSearchFields{
+				"label": name,
+				"names": name,
+				"name":  name,
+			},
+		},
+	}
+}
+
+// searchStoredValues are the fields kept in the view along with the
+// index, the suggestions are labelled from them
+func searchStoredValues() []driver.StoredValue {
+	return []driver.StoredValue{{Fields: []string{"label", "name"}}}
+}
+
+// createSearchView makes sure the search analyzers and the view over the
+// stock and stockprop collections exist. The links of an existing view
+// are replaced, so that the view follows any change of the indexed fields.
+// The stored values could only be set on creation, so a view without them
+// is made again.
+func createSearchView(ar *arangorepository, collP *CollectionParams) error {
+	ctx := context.Background()
+	dbh := ar.database.Handler()
+	for _, def := range searchAnalyzers() {
+		if _, _, err := dbh.EnsureAnalyzer(ctx, def); err != nil {
+			return errors.Errorf("error in creating analyzer %s %s", def.Name, err)
+		}
+	}
+	props := driver.ArangoSearchViewProperties{
+		Links:        searchLinks(ar),
+		StoredValues: searchStoredValues(),
+	}
+	ok, err := dbh.ViewExists(ctx, collP.SearchView)
+	if err != nil {
+		return errors.Errorf("error in finding view %s %s", collP.SearchView, err)
+	}
+	if ok {
+		ok, err = updateSearchView(ctx, dbh, collP.SearchView, props)
+		if err != nil {
+			return err
+		}
+	}
+	if !ok {
+		if _, err := dbh.CreateArangoSearchView(ctx, collP.SearchView, &props); err != nil {
+			return errors.Errorf("error in creating view %s %s", collP.SearchView, err)
+		}
+	}
+	ar.searchView = collP.SearchView
+	return nil
+}
+
+// updateSearchView replaces the links of an existing view. A view with
+// other stored values is removed instead, and false is returned for
+// making it again.
+func updateSearchView(
+	ctx context.Context,
+	dbh driver.Database,
+	name string,
+	props driver.ArangoSearchViewProperties,
+) (bool, error) {
+	view, err := dbh.View(ctx, name)
+	if err != nil {
+		return false, errors.Errorf("error in opening view %s %s", name, err)
+	}
+	sview, err := view.ArangoSearchView()
+	if err != nil {
+		return false, errors.Errorf("view %s is not an arangosearch view %s", name, err)
+	}
+	current, err := sview.Properties(ctx)
+	if err != nil {
+		return false, errors.Errorf("error in reading view %s %s", name, err)
+	}
+	if !sameStoredValues(current.StoredValues, props.StoredValues) {
+		if err := view.Remove(ctx); err != nil {
+			return false, errors.Errorf("error in removing view %s %s", name, err)
+		}
+		return false, nil
+	}
+	if err := sview.SetProperties(ctx, props); err != nil {
+		return false, errors.Errorf("error in updating view %s %s", name, err)
+	}
+	return true, nil
+}
+
+func sameStoredValues(a, b []driver.StoredValue) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if strings.Join(a[i].Fields, ",") != strings.Join(b[i].Fields, ",") {
+			return false
+		}
+	}
+	return true
+}
+
+// SearchStocks gives the stocks that match any word of the query in
+// their summary, genes, label, names or plasmid name, the most relevant
+// first
+func (ar *arangorepository) SearchStocks(
+	ctx context.Context,
+	p *model.SearchParams,
+) ([]*model.StockSearchHit, error) {
+	hits := make([]*model.StockSearchHit, 0)
+	tx := ar.directTx(ctx)
+	var terms []string
+	_, err := tx.getRow(
+		statement.SearchTokens,
+		map[string]interface{}{
+			"query":    p.Query,
+			"analyzer": searchAnalyzer,
+		}, &terms)
+	if err != nil {
+		return hits, errors.Errorf("error in analyzing search query %s", err)
+	}
+	if len(terms) == 0 {
+		return hits, nil
+	}
+	bindVars := map[string]interface{}{
+		"@search_view":     ar.searchView,
+		"stock_collection": ar.stockc.stock.Name(),
+		"stock_prop_graph": ar.stockc.stockPropType.Name(),
+		"analyzer":         searchAnalyzer,
+		"terms":            terms,
+		"stock_type":       nil,
+		"limit":            p.Limit + 1,
+	}
+	if len(p.StockType) > 0 {
+		bindVars["stock_type"] = p.StockType
+	}
+	page := ""
+	if p.Cursor != nil {
+		cond, err := searchPage.cursorCondition(
+			p.Cursor,
+			[]string{"sort_values[0]"},
+			[]string{"<"},
+			bindVars,
+		)
+		if err != nil {
+			return hits, err
+		}
+		page = "FILTER " + cond
+	}
+	ms, err := searchRows[model.StockDoc](
+		tx,
+		fmt.Sprintf(statement.StockSearch, page),
+		bindVars,
+	)
+	if err != nil {
+		return hits, err
+	}
+	for _, m := range ms {
+		hit := &model.StockSearchHit{Stock: m, Highlights: highlights(m, terms)}
+		if len(m.SortValues) > 0 {
+			hit.Score, _ = m.SortValues[0].(float64)
+		}
+		hits = append(hits, hit)
+	}
+	return hits, nil
+}
+
+// highlights gives the snippets of all the searched fields of a stock
+// that have any of the terms
+func highlights(m *model.StockDoc, terms []string) map[string][]string {
+	fields := map[string][]string{
+		"summary": {m.Summary},
+		"gene":    m.Genes,
+	}
+	if m.StrainProperties != nil {
+		fields["label"] = []string{m.StrainProperties.Label}
+		fields["name"] = m.StrainProperties.Names
+	}
+	if m.PlasmidProperties != nil {
+		fields["plasmid_name"] = []string{m.PlasmidProperties.Name}
+	}
+	hl := make(map[string][]string)
+	for field, values := range fields {
+		for _, v := range values {
+			if snippet, ok := highlight(v, terms); ok {
+				hl[field] = append(hl[field], snippet)
+			}
+		}
+	}
+	return hl
+}
+
+// highlight encloses the words of text that start with any of the terms
+// in <em> tags. The terms are stemmed, so a word only needs to begin with
+// one of them. A text longer than snippetLength is cut around its first
+// match.
+func highlight(text string, terms []string) (string, bool) {
+	runes := []rune(text)
+	var spans [][2]int
+	for i := 0; i < len(runes); {
+		if !isWordRune(runes[i]) {
+			i++
+			continue
+		}
+		j := i
+		for j < len(runes) && isWordRune(runes[j]) {
+			j++
+		}
+		if matchesTerm(strings.ToLower(string(runes[i:j])), terms) {
+			spans = append(spans, [2]int{i, j})
+		}
+		i = j
+	}
+	if len(spans) == 0 {
+		return "", false
+	}
+	start, end := 0, len(runes)
+	if len(runes) > snippetLength {
+		start = spans[0][0] - snippetLead
+		if start < 0 {
+			start = 0
+		}
+		end = start + snippetLength
+		if end > len(runes) {
+			end, start = len(runes), len(runes)-snippetLength
+		}
+	}
+	var bldr strings.Builder
+	if start > 0 {
+		bldr.WriteString("…")
+	}
+	pos := start
+	for _, sp := range spans {
+		if sp[0] < start || sp[1] > end {
+			continue
+		}
+		bldr.WriteString(string(runes[pos:sp[0]]))
+		bldr.WriteString("<em>" + string(runes[sp[0]:sp[1]]) + "</em>")
+		pos = sp[1]
+	}
+	bldr.WriteString(string(runes[pos:end]))
+	if end < len(runes) {
+		bldr.WriteString("…")
+	}
+	return bldr.String(), true
+}
+
+func matchesTerm(word string, terms []string) bool {
+	for _, t := range terms {
+		if strings.HasPrefix(word, t) {
+			return true
+		}
+	}
+	return false
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_'
+}
@@ -0,0 +1,114 @@
+package arangodb
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dictyBase/modware-stock/internal/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHighlight(t *testing.T) {
+	t.Parallel()
+	assert := require.New(t)
+	hl, ok := highlight("cAMP receptor null mutant", []string{"receptor", "null"})
+	assert.True(ok, "should match the terms")
+	assert.Equal("cAMP <em>receptor</em> <em>null</em> mutant", hl)
+	hl, ok = highlight("Radiation-sensitive mutants.", []string{"mutant"})
+	assert.True(ok, "should match the stemmed term")
+	assert.Equal("Radiation-sensitive <em>mutants</em>.", hl)
+	_, ok = highlight("Radiation-sensitive mutant.", []string{"receptor"})
+	assert.False(ok, "should not match absent term")
+	long := strings.Repeat("lorem ipsum ", 30) + "receptor" + strings.Repeat(" dolor", 30)
+	hl, ok = highlight(long, []string{"receptor"})
+	assert.True(ok, "should match the term of a long text")
+	assert.True(strings.HasPrefix(hl, "…"), "should cut the start of long text")
+	assert.True(strings.HasSuffix(hl, "…"), "should cut the end of long text")
+	assert.Contains(hl, "<em>receptor</em>")
+	assert.LessOrEqual(len([]rune(hl)), snippetLength+len("<em></em>")+2)
+}
+
+func TestSearchStocks(t *testing.T) {
+	t.Parallel()
+	assert, repo := setUp(t)
+	defer tearDown(repo)
+	err := createTestStrains(5, General, repo)
+	assert.NoError(err, "expect no error from creating strains")
+	ns := newTestStrain("kramer@costanza.com", General)
+	ns.Data.Attributes.Summary = "cAMP receptor null mutant"
+	ns.Data.Attributes.Label = "carA-"
+	m, err := repo.AddStrain(context.Background(), ns)
+	assert.NoError(err, "expect no error from adding strain")
+	_, err = repo.AddPlasmid(context.Background(), newTestPlasmid("kramer@costanza.com"))
+	assert.NoError(err, "expect no error from adding plasmid")
+	// the view is updated in the background after the commit
+	var hits []*model.StockSearchHit
+	assert.Eventually(func() bool {
+		hits, err = repo.SearchStocks(
+			context.Background(),
+			&model.SearchParams{Query: "cAMP receptors", Limit: 10},
+		)
+		return err == nil && len(hits) > 0
+	}, 10*time.Second, 200*time.Millisecond, "should find the strain by summary")
+	assert.Len(hits, 1, "should only find the strain with matching summary")
+	assert.Equal(m.Key, hits[0].Stock.Key, "should match the strain")
+	assert.Greater(hits[0].Score, 0.0, "should have a relevance score")
+	assert.Equal(
+		[]string{"<em>cAMP</em> <em>receptor</em> null mutant"},
+		hits[0].Highlights["summary"],
+		"should highlight the matching words of the summary",
+	)
+	hits, err = repo.SearchStocks(
+		context.Background(),
+		&model.SearchParams{Query: "radiation mutant", Limit: 4},
+	)
+	assert.NoError(err, "expect no error from searching stocks")
+	assert.Len(hits, 5, "should match the provided limit number + 1")
+	for i := 1; i < len(hits); i++ {
+		assert.GreaterOrEqual(
+			hits[i-1].Score, hits[i].Score, "should rank by relevance",
+		)
+	}
+	last := hits[3]
+	rest, err := repo.SearchStocks(
+		context.Background(),
+		&model.SearchParams{
+			Query: "radiation mutant",
+			Limit: 10,
+			Cursor: &model.StockCursor{
+				Values: last.Stock.SortValues,
+				Key:    last.Stock.Key,
+			},
+		},
+	)
+	assert.NoError(err, "expect no error from searching with cursor")
+	assert.Len(rest, 2, "should list the rest of the matching strains")
+	for _, h := range rest {
+		for _, p := range hits[:4] {
+			assert.NotEqual(p.Stock.Key, h.Stock.Key, "should not repeat strains")
+		}
+	}
+	assert.Eventually(func() bool {
+		hits, err = repo.SearchStocks(
+			context.Background(),
+			&model.SearchParams{Query: "plasmid", StockType: "plasmid", Limit: 10},
+		)
+		return err == nil && len(hits) > 0
+	}, 10*time.Second, 200*time.Millisecond, "should find the plasmid by summary")
+	assert.NotNil(hits[0].Stock.PlasmidProperties, "should only find plasmids")
+	hits, err = repo.SearchStocks(
+		context.Background(),
+		&model.SearchParams{Query: "mutant", StockType: "plasmid", Limit: 10},
+	)
+	assert.NoError(err, "expect no error from searching plasmids")
+	assert.Empty(hits, "should not find strains when restricted to plasmids")
+	hits, err = repo.SearchStocks(
+		context.Background(),
+		&model.SearchParams{Query: "gammaS13", StockType: "strain", Limit: 10},
+	)
+	assert.NoError(err, "expect no error from searching by name")
+	assert.NotEmpty(hits, "should find strains by their names")
+	assert.Contains(hits[0].Highlights["name"], "<em>gammaS13</em>")
+}
@@ -0,0 +1,133 @@
+package statement
+
+const (
+	StockReferenceQ = `
+		LET children = (
+			FOR c IN 1..1 OUTBOUND CONCAT(@stock_collection,"/",@id) GRAPH @parent_graph
+				RETURN c.stock_id
+		)
+		LET strains = (
+			FOR stock_prop IN @@stock_properties_collection
+				FILTER stock_prop.plasmid == @id
+				FOR s, e IN 1..1 INBOUND stock_prop GRAPH @stock_prop_graph
+					FILTER e.type == 'strain'
+					RETURN s.stock_id
+		)
+		RETURN { children: children, strains: strains }
+	`
+	StockRemove = `
+		LET sid = CONCAT(@stock_collection,"/",@id)
+		LET types = (
+			FOR e IN @@stock_type_collection
+				FILTER e._from == sid
+				RETURN e
+		)
+		LET terms = (
+			FOR e IN @@stock_term_collection
+				FILTER e._from == sid
+				RETURN e._key
+		)
+		LET parents = (
+			FOR e IN @@parent_strain_collection
+				FILTER e._from == sid OR e._to == sid
+				RETURN e._key
+		)
+		LET rprop = (
+			FOR e IN types
+				REMOVE PARSE_IDENTIFIER(e._to).key IN @@stock_properties_collection
+		)
+		LET rtype = (
+			FOR e IN types
+				REMOVE e._key IN @@stock_type_collection
+		)
+		LET rterm = (
+			FOR k IN terms
+				REMOVE k IN @@stock_term_collection
+		)
+		LET rparent = (
+			FOR k IN parents
+				REMOVE k IN @@parent_strain_collection
+		)
+		REMOVE @id IN @@stock_collection
+	`
+	// StrainDetach clears the plasmid of the strains that refer to a purged
+	// plasmid and marks the strains, given by their keys, as updated
+	StrainDetach = `
+		LET rplasmid = (
+			FOR stock_prop IN @@stock_properties_collection
+				FILTER stock_prop.plasmid == @id
+				UPDATE stock_prop WITH { plasmid: null }
+					IN @@stock_properties_collection
+					OPTIONS { keepNull: false }
+		)
+		FOR k IN @keys
+			UPDATE k WITH {
+				updated_at: DATE_ISO8601(DATE_NOW()),
+				updated_by: @updated_by
+			} IN @@stock_collection
+	`
+	StockSoftRemove = `
+		FOR s IN @@stock_collection
+			FILTER s._key == @key
+			FILTER s.deleted_at == null
+			LET now = DATE_ISO8601(DATE_NOW())
+			UPDATE s WITH {
+				deleted_at: now,
+				deleted_by: @deleted_by,
+				updated_at: now,
+				updated_by: @deleted_by
+			} IN @@stock_collection
+			RETURN NEW._key
+	`
+	StockRestore = `
+		FOR s IN @@stock_collection
+			FILTER s._key == @key
+			FILTER s.deleted_at != null
+			UPDATE s WITH {
+				deleted_at: null,
+				deleted_by: null,
+				updated_at: DATE_ISO8601(DATE_NOW()),
+				updated_by: @restored_by
+			} IN @@stock_collection
+			OPTIONS { keepNull: false }
+			RETURN NEW._key
+	`
+	// DeletedStockList gives the removed stocks, the most recently
+	// removed one first, from the stock that follows the cursor if any
+	DeletedStockList = `
+		FOR s IN @@stock_collection
+			FILTER s.deleted_at != null
+			LET sort_values = [DATE_TIMESTAMP(s.deleted_at)]
+			%s
+			SORT s.deleted_at DESC, s._key DESC
+			LIMIT @limit
+			FOR stock_prop, e IN 1..1 OUTBOUND s GRAPH @stock_prop_graph
+				RETURN MERGE(
+					s,
+					e.type == 'strain' ?
+					{
+						strain_properties: {
+							label: stock_prop.label,
+							species: stock_prop.species,
+							plasmid: stock_prop.plasmid,
+							names: stock_prop.names
+						}
+					} :
+					{
+						plasmid_properties: {
+							image_map: stock_prop.image_map,
+							sequence: stock_prop.sequence,
+							name: stock_prop.name
+						}
+					},
+					{ sort_values: sort_values }
+				)
+	`
+	DeletedStockBeforeQ = `
+		FOR s IN @@stock_collection
+			FILTER s.deleted_at != null
+			FILTER s.deleted_at < DATE_ISO8601(@before)
+			SORT s.deleted_at ASC
+			RETURN s._key
+	`
+)
@@ -0,0 +1,64 @@
+package statement
+
+const (
+	// StrainFacetSource gives the strains to count the facets of, the
+	// values of all facets are gathered while the strain is at hand
+	StrainFacetSource = `
+		LET strains = (
+			FOR strain IN @@stock_collection
+				FILTER strain.deleted_at == null
+				FOR prop, e IN 1..1 OUTBOUND strain GRAPH @stock_prop_graph
+					FILTER e.type == 'strain'
+					` + strainFacetValues + `
+		)
+	`
+	// StrainFacetSourceFilter gives the strains that match the filter,
+	// a strain with more than one term is only taken once
+	StrainFacetSourceFilter = `
+		LET strains = (
+			FOR cvterm IN @@cvterm_collection
+				FOR cv IN @@cv_collection
+					FOR s IN 1..1 INBOUND cvterm GRAPH @stock_cvterm_graph
+						FOR stock_prop, etype IN 1..1 OUTBOUND s GRAPH @stock_prop_graph
+							FILTER cvterm.graph_id == cv._id
+							FILTER etype.type == 'strain'
+							FILTER s.deleted_at == null
+							LET parents = ` + StrainParents + `
+							%s
+							COLLECT key = s._key INTO matched = {
+								strain: s, prop: stock_prop
+							}
+							LET strain = FIRST(matched).strain
+							LET prop = FIRST(matched).prop
+							` + strainFacetValues + `
+		)
+	`
+	strainFacetValues = `
+		LET term = FIRST(
+			FOR cg IN 1..1 OUTBOUND strain GRAPH @stock_cvterm_graph
+				FOR tcv IN @@cv_collection
+					FILTER cg.deprecated == false
+					FILTER cg.graph_id == tcv._id
+					FILTER tcv.metadata.namespace == @ontology
+					RETURN cg.label
+		)
+		RETURN {
+			species: prop.species,
+			depositor: strain.depositor,
+			dicty_strain_property: term,
+			has_plasmid: LENGTH(prop.plasmid) > 0
+		}
+	`
+	// StrainFacetCounts counts the strains by every value of the facets,
+	// it follows one of the strain facet sources
+	StrainFacetCounts = `
+		FOR facet IN @facets
+			LET counts = (
+				FOR m IN strains
+					COLLECT value = TO_STRING(m[facet]) WITH COUNT INTO count
+					SORT count DESC, value ASC
+					RETURN { value: value, count: count }
+			)
+			RETURN { facet: facet, counts: counts }
+	`
+)
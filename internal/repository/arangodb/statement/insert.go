@@ -67,7 +67,14 @@ const (
 			} INTO @@stock_properties_collection RETURN NEW
 		)
 		INSERT { _from: n[0]._id, _to: o[0]._id, type: 'strain' } INTO @@stock_type_collection
-		INSERT { _from: @pid, _to: n[0]._id } INTO @@parent_strain_collection
+		LET parent_edges = (
+			FOR pr IN @parents
+				INSERT {
+					_from: pr.from,
+					_to: n[0]._id,
+					relationship: pr.relationship
+				} INTO @@parent_strain_collection
+		)
 		INSERT { _from: n[0]._id, _to: @to } INTO @@stock_term_collection
 		RETURN MERGE(
 			n[0],
@@ -131,7 +138,14 @@ const (
 			} INTO @@stock_properties_collection RETURN NEW
 		)
 		INSERT { _from: n[0]._id, _to: o[0]._id, type: 'strain' } INTO @@stock_type_collection
-		INSERT { _from: @pid, _to: n[0]._id } INTO @@parent_strain_collection
+		LET parent_edges = (
+			FOR pr IN @parents
+				INSERT {
+					_from: pr.from,
+					_to: n[0]._id,
+					relationship: pr.relationship
+				} INTO @@parent_strain_collection
+		)
 		INSERT { _from: n[0]._id, _to: @to } INTO @@stock_term_collection
 		RETURN MERGE(n[0],{strain_properties: o[0]})
 	`
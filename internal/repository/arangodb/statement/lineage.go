@@ -0,0 +1,77 @@
+package statement
+
+const (
+	// StrainLineage traverses the parent edges from a strain, INBOUND
+	// for its ancestors and OUTBOUND for its descendants. The traversal is
+	// breadth first and visits every relative once, so it is reached by
+	// the shortest path. The edges are restricted by the filter that
+	// follows the traversal. The removed strains are traversed but not
+	// given.
+	StrainLineage = `
+		FOR v, e, p IN 1..@depth %s CONCAT(@stock_collection,"/",@id) GRAPH @parent_graph
+			OPTIONS { order: "bfs", uniqueVertices: "global" }
+			%s
+			FILTER v.deleted_at == null
+			LET s = v
+			LET depth = LENGTH(p.edges)
+			FOR stock_prop, pe IN 1..1 OUTBOUND s GRAPH @stock_prop_graph
+				FILTER pe.type == 'strain'
+				LET sort_values = [depth]
+				%s
+				SORT depth ASC, s._key ASC
+				LIMIT @limit
+				RETURN {
+					strain: %s,
+					depth: depth,
+					path: p.vertices[*].stock_id,
+					relationships: p.edges[
+						* RETURN NOT_NULL(CURRENT.relationship, 'derived_from')
+					]
+				}
+	`
+	// StrainLineageGraph gives the parent edges of the lineage of a strain
+	// along with every strain at either end of them, the strain itself
+	// included. The relatives are traversed as in StrainLineage, but the
+	// removed strains are not traversed. As every relative is only visited
+	// once, the edges are gathered from the relatives afterwards.
+	StrainLineageGraph = `
+		LET members = APPEND([@id], (
+			FOR v, e, p IN 1..@depth %[1]s CONCAT(@stock_collection,"/",@id) GRAPH @parent_graph
+				OPTIONS { order: "bfs", uniqueVertices: "global" }
+				%[2]s
+				FILTER p.vertices[*].deleted_at ALL == null
+				RETURN v._key
+		))
+		LET edges = (
+			FOR key IN members
+				FOR v, e, p IN 1..1 %[1]s CONCAT(@stock_collection,"/",key) GRAPH @parent_graph
+					FILTER v._key IN members
+					%[2]s
+					LET edge = {
+						parent: PARSE_IDENTIFIER(e._from).key,
+						child: PARSE_IDENTIFIER(e._to).key,
+						relationship: NOT_NULL(e.relationship, 'derived_from')
+					}
+					SORT edge.parent ASC, edge.child ASC
+					RETURN edge
+		)
+		LET nodes = (
+			FOR key IN members
+				LET s = DOCUMENT(@stock_collection, key)
+				FOR stock_prop, pe IN 1..1 OUTBOUND s GRAPH @stock_prop_graph
+					FILTER pe.type == 'strain'
+					SORT s._key ASC
+					RETURN {
+						id: s.stock_id,
+						label: stock_prop.label,
+						dicty_strain_property: %[3]s
+					}
+		)
+		RETURN { nodes: nodes, edges: edges }
+	`
+	// LineageRelationshipFilter restricts the edges of a lineage traversal
+	// to the relationships, it is checked on every edge while traversing.
+	// The edges recorded without any relationship are derived from, so
+	// null is bound along with it.
+	LineageRelationshipFilter = `FILTER p.edges[*].relationship ALL IN @relationships`
+)
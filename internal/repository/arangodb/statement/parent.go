@@ -0,0 +1,52 @@
+package statement
+
+const (
+	// StrainParents gives the parents of the strain s along with their
+	// relationship, the edges recorded without any are derived from. The
+	// removed parents are left out.
+	StrainParents = `(
+		FOR pg, pe IN 1..1 INBOUND s GRAPH @parent_graph
+			FILTER pg.deleted_at == null
+			SORT pg.stock_id
+			RETURN {
+				id: pg.stock_id,
+				relationship: NOT_NULL(pe.relationship, 'derived_from')
+			}
+	)`
+	// StrainParentEdges gives every parent edge of the strain s as in
+	// StrainParents, the removed parents included. The revisions record
+	// them, so that a revert keeps the edges to a parent that is restored
+	// later.
+	StrainParentEdges = `(
+		FOR pg, pe IN 1..1 INBOUND s GRAPH @parent_graph
+			SORT pg.stock_id
+			RETURN {
+				id: pg.stock_id,
+				relationship: NOT_NULL(pe.relationship, 'derived_from')
+			}
+	)`
+	// StrainParentsRemove removes all the parent edges of a strain
+	StrainParentsRemove = `
+		FOR e IN @@parent_strain_collection
+			FILTER e._to == CONCAT(@stock_collection,"/",@key)
+			REMOVE e IN @@parent_strain_collection
+	`
+	// StrainDescendantPath gives the stock ids along the shortest path
+	// from a strain down to one of its descendants, it is empty when the
+	// other strain is not a descendant
+	StrainDescendantPath = `
+		FOR v IN OUTBOUND SHORTEST_PATH
+			CONCAT(@stock_collection,"/",@key) TO @descendant
+			GRAPH @parent_graph
+			RETURN v.stock_id
+	`
+	// ParentEdgesQ gives every parent edge as the stock ids of the parent
+	// and the child
+	ParentEdgesQ = `
+		FOR e IN @@parent_strain_collection
+			RETURN {
+				parent: PARSE_IDENTIFIER(e._from).key,
+				child: PARSE_IDENTIFIER(e._to).key
+			}
+	`
+)
@@ -0,0 +1,56 @@
+package statement
+
+const (
+	StockRevert = `
+		LET s = (
+			UPDATE { _key: @key } WITH {
+				updated_at: DATE_ISO8601(DATE_NOW()),
+				updated_by: @updated_by,
+				summary: @summary,
+				editable_summary: @editable_summary,
+				depositor: @depositor,
+				genes: @genes,
+				dbxrefs: @dbxrefs,
+				publications: @publications
+			} IN @@stock_collection
+			OPTIONS { keepNull: false }
+			RETURN NEW
+		)
+		LET p = (
+			UPDATE { _key: @propkey } WITH @stock_prop
+			IN @@stock_properties_collection
+			RETURN NEW
+		)
+		%s
+		RETURN s[0]._key
+	`
+	StockTermRelQ = `
+		FOR e IN @@stock_term_collection
+			FILTER e._from == CONCAT(@stock_collection,"/",@key)
+			RETURN e._key
+	`
+	RevertParentsIns = `
+		LET rp = (
+			FOR pr IN @parents
+				INSERT {
+					_from: pr.from,
+					_to: CONCAT(@stock_collection,'/',@key),
+					relationship: pr.relationship
+				} INTO @@parent_strain_collection
+		)
+	`
+	RevertTermUpd = `
+		LET rt = (
+			UPDATE @tkey WITH { _to: @to } IN @@stock_term_collection
+		)
+	`
+	// RevertTermIns adds the term edge of a strain that has none
+	RevertTermIns = `
+		LET rt = (
+			INSERT {
+				_from: CONCAT(@stock_collection,'/',@key),
+				_to: @to
+			} INTO @@stock_term_collection
+		)
+	`
+)
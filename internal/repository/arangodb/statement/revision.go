@@ -0,0 +1,129 @@
+package statement
+
+const (
+	StockSnapshotQ = `
+		FOR s IN @@stock_collection
+			FILTER s._key == @id
+			FOR stock_prop, e IN 1..1 OUTBOUND s GRAPH @stock_prop_graph
+				LET parents = ` + StrainParentEdges + `
+				LET term = (
+					FOR cg IN 1..1 OUTBOUND s GRAPH @stock_cvterm_graph
+						FOR cv IN @@cv_collection
+							FILTER cg.deprecated == false
+							FILTER cg.graph_id == cv._id
+							FILTER cv.metadata.namespace == @ontology
+							RETURN cg.label
+				)
+				RETURN MERGE(
+					s,
+					e.type == 'strain' ?
+					{
+						strain_properties: {
+							label: stock_prop.label,
+							species: stock_prop.species,
+							plasmid: stock_prop.plasmid,
+							names: stock_prop.names,
+							dicty_strain_property: term[0],
+							parent: parents[0].id,
+							parents: parents
+						}
+					} :
+					{
+						plasmid_properties: {
+							image_map: stock_prop.image_map,
+							sequence: stock_prop.sequence,
+							name: stock_prop.name
+						}
+					}
+				)
+	`
+	StockRevisionIns = `
+		INSERT MERGE(
+			@revision,
+			{ created_at: DATE_ISO8601(DATE_NOW()) }
+		) INTO @@stock_revision_collection
+	`
+	// StockBaselineIns records a revision at the given time
+	StockBaselineIns = `
+		INSERT MERGE(
+			@revision,
+			{ created_at: DATE_ISO8601(@created_at) }
+		) INTO @@stock_revision_collection
+	`
+	// StockUnrevisedQ gives the stocks without any revision
+	StockUnrevisedQ = `
+		FOR s IN @@stock_collection
+			FILTER LENGTH(
+				FOR r IN @@stock_revision_collection
+					FILTER r.stock_id == s._key
+					LIMIT 1
+					RETURN 1
+			) == 0
+			RETURN s._key
+	`
+	// StockRevisedQ tells whether a stock has any revision
+	StockRevisedQ = `
+		RETURN LENGTH(
+			FOR r IN @@stock_revision_collection
+				FILTER r.stock_id == @id
+				LIMIT 1
+				RETURN 1
+		) > 0
+	`
+	// StockRevisionList gives the revisions of a stock, the most recent
+	// one first, from the revision that follows the cursor if any
+	StockRevisionList = `
+		FOR r IN @@stock_revision_collection
+			FILTER r.stock_id == @id
+			LET sort_values = [DATE_TIMESTAMP(r.created_at)]
+			%s
+			SORT r.created_at DESC, TO_NUMBER(r._key) DESC
+			LIMIT @limit
+			RETURN MERGE(r, { sort_values: sort_values })
+	`
+	// StockAsOfQ gives the state of a stock after its last revision at or
+	// before the time, nothing before its first revision
+	StockAsOfQ = `
+		FOR r IN @@stock_revision_collection
+			FILTER r.stock_id == @id
+			FILTER r.created_at <= DATE_ISO8601(@as_of)
+			SORT r.created_at DESC, TO_NUMBER(r._key) DESC
+			LIMIT 1
+			RETURN { doc: r.after }
+	`
+	// StrainListAsOf gives the strains by their last revision at or before
+	// the time, the revisions are read once and only the last of every
+	// stock is fetched
+	StrainListAsOf = strainAsOf + `
+			%s
+			%s
+			LIMIT @limit
+			RETURN MERGE(
+				UNSET(s, 'deleted_at', 'deleted_by'),
+				{ sort_values: sort_values }
+			)
+	`
+	// StrainCountAsOf counts the strains by their last revision at or
+	// before the time
+	StrainCountAsOf = strainAsOf + `
+			%s
+			COLLECT WITH COUNT INTO total
+			RETURN total
+	`
+	strainAsOf = `
+		FOR r IN @@stock_revision_collection
+			FILTER r.created_at <= DATE_ISO8601(@as_of)
+			COLLECT id = r.stock_id
+				AGGREGATE last = MAX([r.created_at, TO_NUMBER(r._key), r._key])
+			LET s = DOCUMENT(@stock_revision_collection, last[2]).after
+			FILTER s.strain_properties != null
+			FILTER s.deleted_at == null
+				OR s.deleted_at > DATE_ISO8601(@as_of)
+			LET stock_prop = s.strain_properties
+			LET parents = NOT_NULL(
+				stock_prop.parents,
+				stock_prop.parent == null ? [] : [
+					{ id: stock_prop.parent, relationship: 'derived_from' }
+				]
+			)`
+)
@@ -0,0 +1,87 @@
+package statement
+
+const (
+	SearchTokens = `RETURN TOKENS(@query, @analyzer)`
+	// StockSearch ranks the stocks by the sum of the scores of their
+	// matching stock and stockprop documents, the stockprop documents are
+	// scored for the stock they belong to
+	StockSearch = `
+		FOR d IN @@search_view
+			SEARCH ANALYZER(
+				d.summary IN @terms
+				OR d.genes IN @terms
+				OR d.label IN @terms
+				OR d.names IN @terms
+				OR d.name IN @terms,
+				@analyzer
+			)
+			LET rank = BM25(d)
+			LET hit = IS_SAME_COLLECTION(@stock_collection, d) ? d : FIRST(
+				FOR st IN 1..1 INBOUND d GRAPH @stock_prop_graph
+					RETURN st
+			)
+			FILTER hit != null
+			COLLECT key = hit._key AGGREGATE score = SUM(rank)
+			LET s = DOCUMENT(@stock_collection, key)
+			FILTER s.deleted_at == null
+			FOR stock_prop, e IN 1..1 OUTBOUND s GRAPH @stock_prop_graph
+				FILTER @stock_type == null OR e.type == @stock_type
+				LET sort_values = [score]
+				%s
+				SORT score DESC, s._key DESC
+				LIMIT @limit
+				RETURN MERGE(
+					s,
+					{
+						prop_rev: stock_prop._rev,
+						sort_values: sort_values,
+						strain_properties: e.type == 'strain' ? {
+							label: stock_prop.label,
+							species: stock_prop.species,
+							plasmid: stock_prop.plasmid,
+							names: stock_prop.names
+						} : null,
+						plasmid_properties: e.type == 'plasmid' ? {
+							image_map: stock_prop.image_map,
+							sequence: stock_prop.sequence,
+							name: stock_prop.name
+						} : null
+					}
+				)
+	`
+	// StockSuggest completes the labels, names and plasmid names being
+	// typed. The values that start with the typed text rank before the
+	// ones that are only similar to it. Only the best ranked candidates
+	// are taken from the view, they are labelled from its stored values.
+	StockSuggest = `
+		LET term = FIRST(TOKENS(@query, @norm_analyzer))
+		FOR d IN @@search_view
+			SEARCH ANALYZER(
+				BOOST(STARTS_WITH(d.label, term), 4)
+				OR BOOST(STARTS_WITH(d.name, term), 4)
+				OR BOOST(STARTS_WITH(d.names, term), 3)
+				OR BOOST(LEVENSHTEIN_MATCH(d.label, term, @distance, true), 2)
+				OR BOOST(LEVENSHTEIN_MATCH(d.name, term, @distance, true), 2)
+				OR LEVENSHTEIN_MATCH(d.names, term, @distance, true),
+				@norm_analyzer
+			)
+			OR NGRAM_MATCH(d.label, term, @threshold, @ngram_analyzer)
+			OR NGRAM_MATCH(d.name, term, @threshold, @ngram_analyzer)
+			OR NGRAM_MATCH(d.names, term, @threshold, @ngram_analyzer)
+			LET rank = BM25(d)
+			SORT rank DESC
+			LIMIT @candidates
+			FOR e IN @@stock_type_collection
+				FILTER e._to == d._id
+				LET s = DOCUMENT(e._from)
+				FILTER s.deleted_at == null
+				LET label = e.type == 'strain' ? d.label : d.name
+				SORT rank DESC, label ASC
+				LIMIT @limit
+				RETURN {
+					id: s.stock_id,
+					label: label,
+					type: e.type
+				}
+	`
+)
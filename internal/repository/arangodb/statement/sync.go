@@ -0,0 +1,78 @@
+package statement
+
+const (
+	// StockChangeList gives the stocks that changed at or after the time
+	// of the cursor, in the order of their change. A removed stock is given
+	// as a tombstone without its document, the stocks that were purged are
+	// found from their purge revision. The dates are compared as they are
+	// stored, so that the indexes on them are used.
+	StockChangeList = `
+		LET live = (
+			FOR s IN @@stock_collection
+				FILTER s.updated_at >= DATE_ISO8601(@since)
+				LET changed = DATE_TIMESTAMP(s.updated_at)
+				FOR stock_prop, e IN 1..1 OUTBOUND s GRAPH @stock_prop_graph
+					LET parents = ` + StrainParents + `
+					LET term = (
+						FOR cg IN 1..1 OUTBOUND s GRAPH @stock_cvterm_graph
+							FOR cv IN @@cv_collection
+								FILTER cg.deprecated == false
+								FILTER cg.graph_id == cv._id
+								FILTER cv.metadata.namespace == @ontology
+								RETURN cg.label
+					)
+					RETURN {
+						key: s._key,
+						type: e.type,
+						changed: changed,
+						deleted: s.deleted_at != null,
+						stock: s.deleted_at != null ? null : MERGE(
+							s,
+							e.type == 'strain' ?
+							{
+								prop_rev: stock_prop._rev,
+								strain_properties: {
+									label: stock_prop.label,
+									species: stock_prop.species,
+									plasmid: stock_prop.plasmid,
+									names: stock_prop.names,
+									dicty_strain_property: term[0],
+									parent: parents[0].id,
+									parents: parents
+								}
+							} :
+							{
+								prop_rev: stock_prop._rev,
+								plasmid_properties: {
+									image_map: stock_prop.image_map,
+									sequence: stock_prop.sequence,
+									name: stock_prop.name
+								}
+							}
+						)
+					}
+		)
+		LET purged = (
+			FOR r IN @@stock_revision_collection
+				FILTER r.action == 'purge'
+				FILTER r.created_at >= DATE_ISO8601(@since)
+				LET changed = DATE_TIMESTAMP(r.created_at)
+				RETURN {
+					key: r.stock_id,
+					type: r.before.strain_properties != null ? 'strain' : 'plasmid',
+					changed: changed,
+					deleted: true,
+					stock: null
+				}
+		)
+		FOR c IN UNION(live, purged)
+			LET sort_values = [c.changed]
+			%s
+			SORT c.changed ASC, c.key ASC
+			LIMIT @limit
+			RETURN MERGE(c, {
+				changed_at: DATE_ISO8601(c.changed),
+				sort_values: sort_values
+			})
+	`
+)
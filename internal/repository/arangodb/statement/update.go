@@ -8,13 +8,18 @@ const (
 	`
 	StrainUpd = `
 		LET s = (
-			UPDATE { _key: @key } WITH { updated_at: DATE_ISO8601(DATE_NOW()), %s }
-			IN @@stock_collection RETURN NEW
+			UPDATE { _key: @key, _rev: @rev }
+				WITH { updated_at: DATE_ISO8601(DATE_NOW()), %s }
+				IN @@stock_collection
+				OPTIONS { ignoreRevs: @ignore_revs }
+				RETURN NEW
 		)
 		LET p = (
-			UPDATE { _key: @propkey } WITH { %s }
+			UPDATE { _key: @propkey, _rev: @prop_rev } WITH { %s }
 			IN @@stock_properties_collection
+			OPTIONS { ignoreRevs: @ignore_revs }
 			RETURN {
+				prop_rev: NEW._rev,
 				strain_properties: {
 					label: NEW.label,
 					species: NEW.species,
@@ -25,15 +30,22 @@ const (
 		)
 		RETURN MERGE(s[0],p[0])
 	`
-	StrainWithNewParentUpd = `
+	// StrainWithParentsUpd updates a strain and adds the edges of its
+	// parents, the earlier edges are removed by StrainParentsRemove
+	StrainWithParentsUpd = `
 		LET s = (
-			UPDATE { _key: @key } WITH { updated_at: DATE_ISO8601(DATE_NOW()), %s }
-			IN @@stock_collection RETURN NEW
+			UPDATE { _key: @key, _rev: @rev }
+				WITH { updated_at: DATE_ISO8601(DATE_NOW()), %s }
+				IN @@stock_collection
+				OPTIONS { ignoreRevs: @ignore_revs }
+				RETURN NEW
 		)
 		LET prop = (
-			UPDATE { _key: @propkey } WITH { %s }
+			UPDATE { _key: @propkey, _rev: @prop_rev } WITH { %s }
 			IN @@stock_properties_collection
+			OPTIONS { ignoreRevs: @ignore_revs }
 			RETURN {
+				prop_rev: NEW._rev,
 				strain_properties: {
 					label: NEW.label,
 					species: NEW.species,
@@ -42,45 +54,30 @@ const (
 				}
 			}
 		)
-		INSERT {
-			_from: CONCAT(@stock_collection,'/',@parent),
-			_to: CONCAT(@stock_collection,'/',@key)
-		} INTO @@parent_strain_collection
-
-		RETURN MERGE(s[0],prop[0])
-	`
-	StrainWithExistingParentUpd = `
-		LET s = (
-			UPDATE { _key: @key } WITH { updated_at: DATE_ISO8601(DATE_NOW()), %s }
-			IN @@stock_collection RETURN NEW
-		)
-		LET prop = (
-			UPDATE { _key: @propkey } WITH { %s }
-			IN @@stock_properties_collection
-			RETURN {
-				strain_properties: {
-					label: NEW.label,
-					species: NEW.species,
-					plasmid: NEW.plasmid,
-					names: NEW.names
-				}
-			}
+		LET parent_edges = (
+			FOR pr IN @parents
+				INSERT {
+					_from: pr.from,
+					_to: CONCAT(@stock_collection,'/',@key),
+					relationship: pr.relationship
+				} INTO @@parent_strain_collection
 		)
-		UPDATE @pkey
-			WITH { _from: CONCAT(@stock_collection,'/',@parent) }
-			IN @@parent_strain_collection
-
 		RETURN MERGE(s[0],prop[0])
 	`
 	PlasmidUpd = `
 		LET s = (
-			UPDATE { _key: @key } WITH { updated_at: DATE_ISO8601(DATE_NOW()), %s }
-			IN @@stock_collection RETURN NEW
+			UPDATE { _key: @key, _rev: @rev }
+				WITH { updated_at: DATE_ISO8601(DATE_NOW()), %s }
+				IN @@stock_collection
+				OPTIONS { ignoreRevs: @ignore_revs }
+				RETURN NEW
 		)
 		LET p = (
-			UPDATE { _key: @propkey } WITH { %s }
+			UPDATE { _key: @propkey, _rev: @prop_rev } WITH { %s }
 			IN @@stock_properties_collection
+			OPTIONS { ignoreRevs: @ignore_revs }
 			RETURN {
+				prop_rev: NEW._rev,
 				plasmid_properties: {
 					image_map: NEW.image_map,
 					sequence: NEW.sequence,
@@ -2,30 +2,320 @@ package arangodb
 
 import (
 	"context"
+	"fmt"
+	"time"
 
-	driver "github.com/arangodb/go-driver"
 	"github.com/cockroachdb/errors"
+	"github.com/dictyBase/modware-stock/internal/model"
+	"github.com/dictyBase/modware-stock/internal/repository"
+	"github.com/dictyBase/modware-stock/internal/repository/arangodb/statement"
 )
 
-// RemoveStock removes a stock
-func (ar *arangorepository) RemoveStock(id string) error {
-	found, err := ar.stockc.stock.DocumentExists(context.Background(), id)
-	if err != nil {
-		return errors.Errorf("error in finding document with id %s %s", id, err)
+type stockReference struct {
+	Children []string `json:"children"`
+	Strains  []string `json:"strains"`
+}
+
+// RemoveStock marks a stock as deleted by recording the time of deletion
+// and the user who removed it, which also counts as its last update. The stock stays in the database and could be
+// brought back with RestoreStock.
+func (ar *arangorepository) RemoveStock(
+	ctx context.Context,
+	id, deletedBy string,
+) error {
+	return ar.withTransaction(ctx, func(tx *dbTx) error {
+		before, err := ar.stockSnapshot(tx, id)
+		if err != nil {
+			return err
+		}
+		var key string
+		found, err := tx.getRow(
+			statement.StockSoftRemove,
+			map[string]interface{}{
+				"key":               id,
+				"deleted_by":        deletedBy,
+				"@stock_collection": ar.stockc.stock.Name(),
+			}, &key)
+		if err != nil {
+			return errors.Errorf("error in removing stock with id %s %s", id, err)
+		}
+		if !found {
+			return errors.Wrapf(
+				repository.ErrStockNotFound, "stock %s is not found", id,
+			)
+		}
+		return ar.addRevision(tx, id, model.RevisionDelete, deletedBy, before)
+	})
+}
+
+// RestoreStock brings back a stock that was removed by RemoveStock
+func (ar *arangorepository) RestoreStock(
+	ctx context.Context,
+	id, restoredBy string,
+) error {
+	return ar.withTransaction(ctx, func(tx *dbTx) error {
+		before, err := ar.stockSnapshot(tx, id)
+		if err != nil {
+			return err
+		}
+		var key string
+		found, err := tx.getRow(
+			statement.StockRestore,
+			map[string]interface{}{
+				"key":               id,
+				"restored_by":       restoredBy,
+				"@stock_collection": ar.stockc.stock.Name(),
+			}, &key)
+		if err != nil {
+			return errors.Errorf("error in restoring stock with id %s %s", id, err)
+		}
+		if !found {
+			return errors.Wrapf(
+				repository.ErrStockNotFound, "deleted stock %s is not found", id,
+			)
+		}
+		return ar.addRevision(tx, id, model.RevisionRestore, restoredBy, before)
+	})
+}
+
+var deletedPage = pageQuery{
+	vars: map[string]bool{"s": true},
+	key:  "s._key",
+}
+
+// ListDeletedStocks provides a list of removed stocks, the most recently
+// removed one comes first. The stocks removed at the same time are ordered
+// by their key.
+func (ar *arangorepository) ListDeletedStocks(
+	ctx context.Context,
+	cursor *model.StockCursor,
+	limit int64,
+) ([]*model.StockDoc, error) {
+	bindVars := map[string]interface{}{
+		"@stock_collection": ar.stockc.stock.Name(),
+		"stock_prop_graph":  ar.stockc.stockPropType.Name(),
+		"limit":             limit + 1,
 	}
-	if !found {
-		return errors.Errorf("document not found %s", id)
+	page := ""
+	if cursor != nil {
+		cond, err := deletedPage.cursorCondition(
+			cursor,
+			[]string{"sort_values[0]"},
+			[]string{"<"},
+			bindVars,
+		)
+		if err != nil {
+			return []*model.StockDoc{}, err
+		}
+		page = "FILTER " + cond
 	}
-	_, err = ar.stockc.stock.RemoveDocument(
-		driver.WithSilent(context.Background()),
-		id,
+	return searchRows[model.StockDoc](
+		ar.directTx(ctx),
+		fmt.Sprintf(statement.DeletedStockList, page),
+		bindVars,
 	)
+}
+
+// PurgeDeletedStocks permanently removes all stocks that were deleted
+// before the given time. Stocks that are still referenced by other strains
+// are left in place. The purges are recorded as made by purgedBy. It returns
+// the identifiers of the purged stocks.
+func (ar *arangorepository) PurgeDeletedStocks(
+	ctx context.Context,
+	before time.Time,
+	purgedBy string,
+) ([]string, error) {
+	ids := make([]string, 0)
+	keys, err := searchRows[string](
+		ar.directTx(ctx),
+		statement.DeletedStockBeforeQ,
+		map[string]interface{}{
+			"@stock_collection": ar.stockc.stock.Name(),
+			"before":            before.UnixMilli(),
+		})
+	if err != nil {
+		return ids, errors.Errorf("error in finding deleted stocks %s", err)
+	}
+	for _, key := range keys {
+		err := ar.PurgeStock(ctx, *key, purgedBy, false)
+		if errors.Is(err, repository.ErrStockInUse) {
+			continue
+		}
+		if err != nil {
+			return ids, err
+		}
+		ids = append(ids, *key)
+	}
+	return ids, nil
+}
+
+// PurgeStock permanently removes a stock that was removed by RemoveStock,
+// along with its stockprop document and all of its stock_type, stock_term
+// and parent_strain edges. It fails with repository.ErrStockNotDeleted for
+// a stock that is not removed. Unless
+// cascade is set, the removal is refused when other strains list the stock
+// as their parent or refer to it as their plasmid. With cascade, such
+// strains lose the parent or the plasmid, and their change is recorded as
+// made by purgedBy.
+func (ar *arangorepository) PurgeStock(
+	ctx context.Context,
+	id, purgedBy string,
+	cascade bool,
+) error {
+	return ar.withTransaction(ctx, func(tx *dbTx) error {
+		var deleted bool
+		found, err := tx.getRow(
+			statement.StockDeletedQ,
+			map[string]interface{}{
+				"key":               id,
+				"@stock_collection": ar.stockc.stock.Name(),
+			}, &deleted)
+		if err != nil {
+			return errors.Errorf(
+				"error in finding document with id %s %s",
+				id, err,
+			)
+		}
+		if !found {
+			return errors.Wrapf(
+				repository.ErrStockNotFound, "stock %s is not found", id,
+			)
+		}
+		if !deleted {
+			return errors.Wrapf(
+				repository.ErrStockNotDeleted,
+				"stock %s has to be removed before it is purged", id,
+			)
+		}
+		ref, err := ar.stockReference(tx, id)
+		if err != nil {
+			return err
+		}
+		if !cascade {
+			if err := ref.check(id); err != nil {
+				return err
+			}
+		}
+		detached := ref.strainIds()
+		befores := make([]*model.StockDoc, 0, len(detached))
+		for _, sid := range detached {
+			sb, err := ar.stockSnapshot(tx, sid)
+			if err != nil {
+				return err
+			}
+			befores = append(befores, sb)
+		}
+		before, err := ar.stockSnapshot(tx, id)
+		if err != nil {
+			return err
+		}
+		err = tx.do(
+			statement.StockRemove,
+			map[string]interface{}{
+				"id":                           id,
+				"stock_collection":             ar.stockc.stock.Name(),
+				"@stock_collection":            ar.stockc.stock.Name(),
+				"@stock_properties_collection": ar.stockc.stockProp.Name(),
+				"@stock_type_collection":       ar.stockc.stockType.Name(),
+				"@stock_term_collection":       ar.stockc.stockTerm.Name(),
+				"@parent_strain_collection":    ar.stockc.parentStrain.Name(),
+			})
+		if err != nil {
+			return errors.Errorf(
+				"error in removing document with id %s %s",
+				id,
+				err,
+			)
+		}
+		if err := ar.addRevision(
+			tx, id, model.RevisionPurge, purgedBy, before,
+		); err != nil {
+			return err
+		}
+		if len(detached) == 0 {
+			return nil
+		}
+		err = tx.do(
+			statement.StrainDetach,
+			map[string]interface{}{
+				"id":                           id,
+				"keys":                         detached,
+				"updated_by":                   purgedBy,
+				"@stock_collection":            ar.stockc.stock.Name(),
+				"@stock_properties_collection": ar.stockc.stockProp.Name(),
+			})
+		if err != nil {
+			return errors.Errorf(
+				"error in detaching strains from stock %s %s",
+				id, err,
+			)
+		}
+		for i, sid := range detached {
+			if err := ar.addRevision(
+				tx, sid, model.RevisionUpdate, purgedBy, befores[i],
+			); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// stockReference gives the strains that list the stock as their parent
+// or refer to it as their plasmid
+func (ar *arangorepository) stockReference(
+	tx *dbTx,
+	id string,
+) (*stockReference, error) {
+	ref := &stockReference{}
+	_, err := tx.getRow(
+		statement.StockReferenceQ,
+		map[string]interface{}{
+			"id":                           id,
+			"stock_collection":             ar.stockc.stock.Name(),
+			"parent_graph":                 ar.stockc.strain2Parent.Name(),
+			"stock_prop_graph":             ar.stockc.stockPropType.Name(),
+			"@stock_properties_collection": ar.stockc.stockProp.Name(),
+		}, ref)
 	if err != nil {
-		return errors.Errorf(
-			"error in removing document with id %s %s",
+		return ref, errors.Errorf(
+			"error in finding references of stock %s %s",
 			id,
 			err,
 		)
 	}
+	return ref, nil
+}
+
+// check fails with repository.ErrStockInUse if any strain refers to the
+// stock
+func (ref *stockReference) check(id string) error {
+	if len(ref.Children) > 0 {
+		return errors.Wrapf(
+			repository.ErrStockInUse,
+			"stock %s is the parent of strains %v",
+			id, ref.Children,
+		)
+	}
+	if len(ref.Strains) > 0 {
+		return errors.Wrapf(
+			repository.ErrStockInUse,
+			"plasmid %s is referenced by strains %v",
+			id, ref.Strains,
+		)
+	}
 	return nil
 }
+
+// strainIds gives every strain that refers to the stock once
+func (ref *stockReference) strainIds() []string {
+	seen := make(map[string]bool)
+	ids := make([]string, 0, len(ref.Children)+len(ref.Strains))
+	for _, sid := range append(append([]string{}, ref.Children...), ref.Strains...) {
+		if !seen[sid] {
+			seen[sid] = true
+			ids = append(ids, sid)
+		}
+	}
+	return ids
+}
@@ -1,6 +1,7 @@
 package arangodb
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/cockroachdb/errors"
@@ -9,123 +10,169 @@ import (
 	"github.com/dictyBase/modware-stock/internal/repository/arangodb/statement"
 )
 
-// GetStrain retrieves a strain from the database
-func (ar *arangorepository) GetStrain(id string) (*model.StockDoc, error) {
+// GetStrain retrieves a strain from the database, limited to the selected
+// fields if there are any
+func (ar *arangorepository) GetStrain(
+	ctx context.Context,
+	id string,
+	fields ...string,
+) (*model.StockDoc, error) {
 	m := &model.StockDoc{}
-	r, err := ar.database.GetRow(
-		statement.StockGetStrain,
-		map[string]interface{}{
-			"id":                 id,
-			"stock_cvterm_graph": ar.stockc.stockOnto.Name(),
-			"ontology":           ar.strainOnto,
-			"stock_collection":   ar.stockc.stock.Name(),
-			"parent_graph":       ar.stockc.strain2Parent.Name(),
-			"stock_prop_graph":   ar.stockc.stockPropType.Name(),
-			"@stock_collection":  ar.stockc.stock.Name(),
-			"@cv_collection":     ar.ontoc.Cv.Name(),
-		})
+	bindVars := map[string]interface{}{
+		"id":                id,
+		"stock_prop_graph":  ar.stockc.stockPropType.Name(),
+		"@stock_collection": ar.stockc.stock.Name(),
+	}
+	proj, err := ar.project(strainProjection, fields, false, bindVars)
+	if err != nil {
+		return m, err
+	}
+	found, err := ar.directTx(ctx).getRow(
+		fmt.Sprintf(statement.StockGetStrain, proj), bindVars, m,
+	)
 	if err != nil {
 		return m, errors.Errorf("error in finding strain id %s %s", id, err)
 	}
-	if r.IsEmpty() {
+	if !found {
 		m.NotFound = true
-		return m, nil
 	}
-	err = r.Read(m)
-	return m, err
+	return m, nil
 }
 
 // ListStrains provides a list of all strains
 func (ar *arangorepository) ListStrains(
-	param *stock.StockParameters,
+	ctx context.Context,
+	param *model.ListParams,
 ) ([]*model.StockDoc, error) {
-	omd := make([]*model.StockDoc, 0)
-	stmt, paramsBind := ar.strainStmtNoFilter(param)
-	if len(param.Filter) > 0 {
-		stmt, paramsBind = ar.strainStmtWithFilter(param)
-	}
-	rs, err := ar.database.SearchRows(stmt, paramsBind)
+	filter, filterVars, err := filterClause(param.Filter, strainTermPage)
 	if err != nil {
-		return omd, err
+		return []*model.StockDoc{}, err
 	}
-	if rs.IsEmpty() {
-		return omd, nil
+	stmt, paramsBind, err := ar.strainStmtNoFilter(param)
+	// the filter statement also binds the ontology term and the parents
+	// for sorting by them
+	if len(filter) > 0 || sortsByTraversal(param.Sort) {
+		stmt, paramsBind, err = ar.strainStmtWithFilter(param, filter)
+		mergeBindVars(paramsBind, filterVars)
 	}
-	for rs.Scan() {
-		m := &model.StockDoc{}
-		if err := rs.Read(m); err != nil {
-			return omd, err
-		}
-		omd = append(omd, m)
+	if err != nil {
+		return []*model.StockDoc{}, err
 	}
-	return omd, nil
+	return searchRows[model.StockDoc](ar.directTx(ctx), stmt, paramsBind)
 }
 
 func (ar *arangorepository) ListStrainsByIds(
+	ctx context.Context,
 	p *stock.StockIdList,
+	fields ...string,
 ) ([]*model.StockDoc, error) {
-	ms := make([]*model.StockDoc, 0)
-	rs, err := ar.database.SearchRows(
-		statement.StrainListFromIds,
-		map[string]interface{}{
-			"ids":                p.Id,
-			"limit":              len(p.Id),
-			"ontology":           ar.strainOnto,
-			"stock_collection":   ar.stockc.stock.Name(),
-			"stock_cvterm_graph": ar.stockc.stockOnto.Name(),
-			"stock_prop_graph":   ar.stockc.stockPropType.Name(),
-			"parent_graph":       ar.stockc.strain2Parent.Name(),
-			"@stock_collection":  ar.stockc.stock.Name(),
-			"@cv_collection":     ar.ontoc.Cv.Name(),
-		})
-	if err != nil {
-		return ms, err
-	}
-	if rs.IsEmpty() {
-		return ms, nil
+	bindVars := map[string]interface{}{
+		"ids":               p.Id,
+		"limit":             len(p.Id),
+		"stock_prop_graph":  ar.stockc.stockPropType.Name(),
+		"@stock_collection": ar.stockc.stock.Name(),
 	}
-	for rs.Scan() {
-		m := &model.StockDoc{}
-		if err := rs.Read(m); err != nil {
-			return ms, err
-		}
-		ms = append(ms, m)
+	proj, err := ar.project(strainProjection, fields, false, bindVars)
+	if err != nil {
+		return []*model.StockDoc{}, err
 	}
-	return ms, nil
+	return searchRows[model.StockDoc](
+		ar.directTx(ctx),
+		fmt.Sprintf(statement.StrainListFromIds, proj),
+		bindVars,
+	)
 }
 
 func (ar *arangorepository) strainStmtWithFilter(
-	param *stock.StockParameters,
-) (string, map[string]interface{}) {
+	param *model.ListParams,
+	filter string,
+) (string, map[string]interface{}, error) {
 	stmtMap := map[string]interface{}{
 		"@cvterm_collection": ar.ontoc.Term.Name(),
 		"@cv_collection":     ar.ontoc.Cv.Name(),
 		"stock_cvterm_graph": ar.stockc.stockOnto.Name(),
 		"stock_prop_graph":   ar.stockc.stockPropType.Name(),
+		"parent_graph":       ar.stockc.strain2Parent.Name(),
 		"limit":              param.Limit + 1,
 	}
-	if param.Cursor != 0 { // no cursor so return first set of results with filter
-		stmt := fmt.Sprintf(statement.StrainListFilterWithCursor, param.Filter)
-		stmtMap["cursor"] = param.Cursor
-		return stmt, stmtMap
+	page, err := strainTermPage.clause(param, stmtMap)
+	if err != nil {
+		return "", stmtMap, err
+	}
+	proj, err := ar.project(strainProjection, param.Fields, true, stmtMap)
+	if err != nil {
+		return "", stmtMap, err
 	}
-	stmt := fmt.Sprintf(statement.StrainListFilter, param.Filter)
-	return stmt, stmtMap
+	return fmt.Sprintf(
+		statement.StrainListFilter, filter, page, proj,
+	), stmtMap, nil
 }
 
 func (ar *arangorepository) strainStmtNoFilter(
-	param *stock.StockParameters,
-) (string, map[string]interface{}) {
-	stmt := statement.StrainList
+	param *model.ListParams,
+) (string, map[string]interface{}, error) {
 	stmtMap := map[string]interface{}{
 		"@stock_collection": ar.stockc.stock.Name(),
 		"stock_prop_graph":  ar.stockc.stockPropType.Name(),
 		"limit":             param.Limit + 1,
 	}
-	if param.Cursor != 0 {
-		stmt = statement.StrainListWithCursor
-		stmtMap["cursor"] = param.Cursor
+	page, err := strainPage.clause(param, stmtMap)
+	if err != nil {
+		return "", stmtMap, err
+	}
+	proj, err := ar.project(strainProjection, param.Fields, true, stmtMap)
+	if err != nil {
+		return "", stmtMap, err
+	}
+	return fmt.Sprintf(statement.StrainList, page, proj), stmtMap, nil
+}
+
+// CountStrains counts all the strains that match the filter. A filter is
+// counted through the same source as the list, where a strain with more
+// than one term is only taken once.
+func (ar *arangorepository) CountStrains(
+	ctx context.Context,
+	filter string,
+) (int64, error) {
+	clause, bindVars, err := filterClause(filter, strainTermPage)
+	if err != nil {
+		return 0, err
+	}
+	if len(clause) == 0 {
+		return ar.countStocks(ctx, "strain", clause, bindVars)
+	}
+	bindVars["@cvterm_collection"] = ar.ontoc.Term.Name()
+	bindVars["@cv_collection"] = ar.ontoc.Cv.Name()
+	bindVars["stock_cvterm_graph"] = ar.stockc.stockOnto.Name()
+	bindVars["stock_prop_graph"] = ar.stockc.stockPropType.Name()
+	bindVars["parent_graph"] = ar.stockc.strain2Parent.Name()
+	var total int64
+	_, err = ar.directTx(ctx).getRow(
+		fmt.Sprintf(statement.StrainCountFilter, clause),
+		bindVars, &total,
+	)
+	if err != nil {
+		return 0, errors.Errorf("error in counting strains %s", err)
 	}
+	return total, nil
+}
 
-	return stmt, stmtMap
+// countStocks counts the stocks of a type that match the filter clause
+func (ar *arangorepository) countStocks(
+	ctx context.Context,
+	stockType, clause string,
+	bindVars map[string]interface{},
+) (int64, error) {
+	bindVars["@stock_collection"] = ar.stockc.stock.Name()
+	bindVars["stock_prop_graph"] = ar.stockc.stockPropType.Name()
+	bindVars["stock_type"] = stockType
+	var total int64
+	_, err := ar.directTx(ctx).getRow(
+		fmt.Sprintf(statement.StockCount, clause),
+		bindVars, &total,
+	)
+	if err != nil {
+		return 0, errors.Errorf("error in counting %s stocks %s", stockType, err)
+	}
+	return total, nil
 }
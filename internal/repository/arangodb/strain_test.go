@@ -1,6 +1,7 @@
 package arangodb
 
 import (
+	"context"
 	"fmt"
 	"regexp"
 	"testing"
@@ -16,34 +17,17 @@ import (
 )
 
 const (
-	filterOne = `FILTER s.depositor == 'george@costanza.com'`
-	filterTwo = `FILTER s.depositor == 'george@costanza.com' 
-		      AND s.depositor == 'rg@gmail.com'
-	 	     `
-	filterThree = `LET x = (
-				FILTER 'gammaS13' IN s.names 
-				RETURN 1
-			)`
-	filterFour          = `FILTER s.created_at <= DATE_ISO8601('2019')`
-	filterFive          = `FILTER stock_prop.label =~ 'yS'`
-	filterSix           = `FILTER s.summary =~ 'mutant'`
-	filterRegularStrain = `FILTER cv.metadata.namespace == 'dicty_strain_property'
-				AND cvterm.label == 'general strain'
-	`
-	filterGwdiStrain = `FILTER cv.metadata.namespace == 'dicty_strain_property'
-				AND cvterm.label == 'REMI-seq'
-	`
-	filterBacterialStrain = `FILTER cv.metadata.namespace == 'dicty_strain_property'
-				AND cvterm.label == 'bacterial strain'
-	`
-	filterAllStrain = `FILTER cv.metadata.namespace == 'dicty_strain_property'
-				AND (
-					cvterm.label == 'bacterial strain'
-					OR cvterm.label == 'REMI-seq'
-					OR cvterm.label == 'general strain'
-				)
-	`
-	filterBad = `FILTER borat.acting == 'funny`
+	filterOne             = "depositor==george@costanza.com"
+	filterTwo             = "depositor==george@costanza.com;depositor==rg@gmail.com"
+	filterThree           = "name@==gammaS13"
+	filterFour            = "created_at$<=2019"
+	filterFive            = "label=~yS"
+	filterSix             = "summary=~mutant"
+	filterRegularStrain   = "ontology==dicty_strain_property;tag==general strain"
+	filterGwdiStrain      = "ontology==dicty_strain_property;tag==REMI-seq"
+	filterBacterialStrain = "ontology==dicty_strain_property;tag==bacterial strain"
+	filterAllStrain       = "ontology==dicty_strain_property;tag==bacterial strain,tag==REMI-seq,tag==general strain"
+	filterBad             = `FILTER borat.acting == 'funny`
 )
 
 func createTestStrainsWithParent(
@@ -63,7 +47,7 @@ func createTestStrainsWithParent(
 			stype,
 		)
 		ns.Data.Attributes.Parent = pid
-		nps, err := repo.AddStrain(ns)
+		nps, err := repo.AddStrain(context.Background(), ns)
 		if err != nil {
 			return ids, err
 		}
@@ -88,7 +72,7 @@ func createTestStrainsWithIDs(
 			),
 			stype,
 		)
-		nps, err := repo.AddStrain(ns)
+		nps, err := repo.AddStrain(context.Background(), ns)
 		if err != nil {
 			return ids, err
 		}
@@ -112,7 +96,7 @@ func createTestStrains(
 			),
 			stype,
 		)
-		_, err := repo.AddStrain(ns)
+		_, err := repo.AddStrain(context.Background(), ns)
 		if err != nil {
 			return err
 		}
@@ -145,7 +129,7 @@ func TestLoadStrainWithID(t *testing.T) {
 			},
 		},
 	}
-	m, err := repo.LoadStrain("DBS0252873", nsp)
+	m, err := repo.LoadStrain(context.Background(), "DBS0252873", nsp)
 	assert.NoErrorf(err, "expect no error, received %s", err)
 	assert.True(m.CreatedAt.Equal(tm), "should match created_at")
 	assert.Equal("DBS0252873", m.StockID, "should match given stock id")
@@ -221,7 +205,7 @@ func setUpTestData(
 			},
 		},
 	}
-	pst, err := repo.LoadStrain("DBS0252873", est)
+	pst, err := repo.LoadStrain(context.Background(), "DBS0252873", est)
 	assert.NoErrorf(err, "expect no error, received %s", err)
 	ns := &stock.ExistingStrain{
 		Data: &stock.ExistingStrain_Data{
@@ -252,7 +236,7 @@ func TestLoadStockWithParent(t *testing.T) {
 	defer tearDown(repo)
 
 	ns := setUpTestData(repo, assert)
-	m2, err := repo.LoadStrain("DBS0235412", ns)
+	m2, err := repo.LoadStrain(context.Background(), "DBS0235412", ns)
 	assert.NoErrorf(err, "expect no error, received %s", err)
 
 	assert.Equal("DBS0235412", m2.StockID, "should match given stock id")
@@ -316,7 +300,8 @@ func TestListStrainsWithType(t *testing.T) {
 	gwids, err := createTestStrainsWithIDs(15, Gwdi, repo)
 	assert.NoError(err, "expect no error from creating gwdi strains")
 	gwStrains, err := repo.ListStrains(
-		&stock.StockParameters{Limit: 100, Filter: filterGwdiStrain},
+		context.Background(),
+		&model.ListParams{Limit: 100, Filter: filterGwdiStrain},
 	)
 	assert.NoError(err, "expect no error in getting list of strains")
 	assert.Lenf(
@@ -328,7 +313,8 @@ func TestListStrainsWithType(t *testing.T) {
 	rids, err := createTestStrainsWithIDs(10, General, repo)
 	assert.NoError(err, "expect no error from creating regular strains")
 	regStrains, err := repo.ListStrains(
-		&stock.StockParameters{Limit: 100, Filter: filterRegularStrain},
+		context.Background(),
+		&model.ListParams{Limit: 100, Filter: filterRegularStrain},
 	)
 	assert.NoError(err, "expect no error in getting list of strains")
 	assert.Lenf(
@@ -340,7 +326,8 @@ func TestListStrainsWithType(t *testing.T) {
 	bids, err := createTestStrainsWithIDs(10, Bacterial, repo)
 	assert.NoError(err, "expect no error from creating bacterial strains")
 	bacStrains, err := repo.ListStrains(
-		&stock.StockParameters{Limit: 100, Filter: filterBacterialStrain},
+		context.Background(),
+		&model.ListParams{Limit: 100, Filter: filterBacterialStrain},
 	)
 	assert.NoError(err, "expect no error in getting list of strains")
 	assert.Lenf(
@@ -350,7 +337,8 @@ func TestListStrainsWithType(t *testing.T) {
 		len(bacStrains),
 	)
 	allStrains, err := repo.ListStrains(
-		&stock.StockParameters{Limit: 99, Filter: filterAllStrain},
+		context.Background(),
+		&model.ListParams{Limit: 99, Filter: filterAllStrain},
 	)
 	assert.NoError(err, "expect no error in getting list of strains")
 	assert.Lenf(
@@ -378,7 +366,8 @@ func TestListStrainsWithFilter(t *testing.T) {
 	err := createTestStrains(10, General, repo)
 	assert.NoError(err, "expect no error from creating strains")
 	sf, err := repo.ListStrains(
-		&stock.StockParameters{Limit: 10, Filter: filterOne},
+		context.Background(),
+		&model.ListParams{Limit: 10, Filter: filterOne},
 	)
 	assert.NoError(err, "expect no error in getting list of strains")
 	assert.Len(sf, 10, "should list ten strains")
@@ -391,7 +380,8 @@ func TestListStrainsWithFilter(t *testing.T) {
 		assert.Equal(m.StrainProperties.Label, "yS13", "should match label")
 	}
 	n, err := repo.ListStrains(
-		&stock.StockParameters{Limit: 100, Filter: filterTwo},
+		context.Background(),
+		&model.ListParams{Limit: 100, Filter: filterTwo},
 	)
 	assert.NoError(
 		err,
@@ -400,8 +390,9 @@ func TestListStrainsWithFilter(t *testing.T) {
 	assert.Len(n, 0, "should list no strains")
 	// do a check for array filter
 	as, err := repo.ListStrains(
-		&stock.StockParameters{
-			Cursor: toTimestamp(sf[5].CreatedAt),
+		context.Background(),
+		&model.ListParams{
+			Cursor: createdCursor(sf[5].CreatedAt),
 			Limit:  10,
 			Filter: filterThree,
 		},
@@ -412,8 +403,9 @@ func TestListStrainsWithFilter(t *testing.T) {
 	)
 	assert.Len(as, 5, "should list five strains")
 	da, err := repo.ListStrains(
-		&stock.StockParameters{
-			Cursor: toTimestamp(sf[5].CreatedAt),
+		context.Background(),
+		&model.ListParams{
+			Cursor: createdCursor(sf[5].CreatedAt),
 			Limit:  10,
 			Filter: filterFour,
 		},
@@ -424,7 +416,8 @@ func TestListStrainsWithFilter(t *testing.T) {
 	)
 	assert.Len(da, 0, "should list no strains")
 	ff, err := repo.ListStrains(
-		&stock.StockParameters{Limit: 10, Filter: filterFive},
+		context.Background(),
+		&model.ListParams{Limit: 10, Filter: filterFive},
 	)
 	assert.NoError(
 		err,
@@ -432,12 +425,14 @@ func TestListStrainsWithFilter(t *testing.T) {
 	)
 	assert.Len(ff, 10, "should list ten strains")
 	fs, err := repo.ListStrains(
-		&stock.StockParameters{Limit: 10, Filter: filterSix},
+		context.Background(),
+		&model.ListParams{Limit: 10, Filter: filterSix},
 	)
 	assert.NoError(err, "expect no error in matching summary substring")
 	assert.Len(fs, 10, "should list ten strains")
 	_, err = repo.ListStrains(
-		&stock.StockParameters{Limit: 2, Filter: filterBad},
+		context.Background(),
+		&model.ListParams{Limit: 2, Filter: filterBad},
 	)
 	assert.Error(err, "expect have error with the query")
 }
@@ -450,7 +445,7 @@ func TestListStrains(t *testing.T) {
 	err := createTestStrains(10, General, repo)
 	assert.NoError(err, "expect no error from creating strains")
 	// get first five results
-	ls, err := repo.ListStrains(&stock.StockParameters{Limit: 4})
+	ls, err := repo.ListStrains(context.Background(), &model.ListParams{Limit: 4})
 	assert.NoError(err, "expect no error in getting first five stocks")
 	assert.Len(ls, 5, "should match the provided limit number + 1")
 	for _, stock := range ls {
@@ -473,10 +468,10 @@ func TestListStrains(t *testing.T) {
 	)
 	// convert fifth result to numeric timestamp in milliseconds
 	// so we can use this as cursor
-	ti := toTimestamp(ls[4].CreatedAt)
+	ti := createdCursor(ls[4].CreatedAt)
 
 	// get next five results (5-9)
-	ls2, err := repo.ListStrains(&stock.StockParameters{Cursor: ti, Limit: 4})
+	ls2, err := repo.ListStrains(context.Background(), &model.ListParams{Cursor: ti, Limit: 4})
 	assert.NoError(err, "expect no error in getting stocks 5-9")
 	assert.Len(ls2, 5, "should match the provided limit number + 1")
 	assert.Exactly(
@@ -491,9 +486,9 @@ func TestListStrains(t *testing.T) {
 	)
 
 	// convert ninth result to numeric timestamp
-	ti2 := toTimestamp(ls2[len(ls2)-1].CreatedAt)
+	ti2 := createdCursor(ls2[len(ls2)-1].CreatedAt)
 	// get last results (9-10)
-	ls3, err := repo.ListStrains(&stock.StockParameters{Cursor: ti2, Limit: 4})
+	ls3, err := repo.ListStrains(context.Background(), &model.ListParams{Cursor: ti2, Limit: 4})
 	assert.NoErrorf(
 		err,
 		"expect no error in getting stocks 9-10, received %s",
@@ -520,7 +515,7 @@ func TestListStrainsByIds(t *testing.T) {
 	ids, err := createTestStrainsWithIDs(30, General, repo)
 	assert.NoError(err, "expect no error from creating strains")
 	// get first five results
-	ls, err := repo.ListStrainsByIds(&stock.StockIdList{Id: ids})
+	ls, err := repo.ListStrainsByIds(context.Background(), &stock.StockIdList{Id: ids})
 	assert.NoError(err, "expect no error in getting strains")
 	assert.Len(ls, 30, "should match the provided limit number")
 	for _, stock := range ls {
@@ -546,7 +541,7 @@ func TestListStrainsByIds(t *testing.T) {
 		)
 	}
 	// strain with parents
-	pm, err := repo.AddStrain(newTestParentStrain("j@peterman.org"))
+	pm, err := repo.AddStrain(context.Background(), newTestParentStrain("j@peterman.org"))
 	assert.NoErrorf(
 		err,
 		"expect no error in creating parent strain, received %s",
@@ -554,7 +549,7 @@ func TestListStrainsByIds(t *testing.T) {
 	)
 	pids, err := createTestStrainsWithParent(30, General, repo, pm.StockID)
 	assert.NoError(err, "expect no error from creating strains")
-	pls, err := repo.ListStrainsByIds(&stock.StockIdList{Id: pids})
+	pls, err := repo.ListStrainsByIds(context.Background(), &stock.StockIdList{Id: pids})
 	assert.NoError(err, "expect no error in getting 30 stocks with parents")
 	assert.Len(pls, 30, "should match the provided limit number")
 	for _, stock := range pls {
@@ -582,6 +577,7 @@ func TestListStrainsByIds(t *testing.T) {
 	}
 	// Non-existing ids
 	els, err := repo.ListStrainsByIds(
+		context.Background(),
 		&stock.StockIdList{Id: []string{"DBN589343", "DBN48473232"}},
 	)
 	assert.NoErrorf(
@@ -597,9 +593,9 @@ func TestGetStrain(t *testing.T) {
 	assert, repo := setUp(t)
 	defer tearDown(repo)
 	ns := newTestStrain("george@costanza.com", General)
-	m, err := repo.AddStrain(ns)
+	m, err := repo.AddStrain(context.Background(), ns)
 	assert.NoErrorf(err, "expect no error, received %s", err)
-	g, err := repo.GetStrain(m.StockID)
+	g, err := repo.GetStrain(context.Background(), m.StockID)
 	assert.NoErrorf(err, "expect no error, received %s", err)
 	assertRegexp(assert, g.StockID)
 	assertStrainProperties(assert, g, ns)
@@ -701,7 +697,7 @@ func TestAddParentStrain(t *testing.T) {
 	assert, repo := setUp(t)
 	defer tearDown(repo)
 	nsp := newTestParentStrain("todd@gagg.com")
-	m, err := repo.AddStrain(nsp)
+	m, err := repo.AddStrain(context.Background(), nsp)
 	assert.NoErrorf(err, "expect no error, received %s", err)
 	assert.Regexp(
 		regexp.MustCompile(`^DBS0\d{6,}$`),
@@ -764,7 +760,7 @@ func testAddChildStrain(
 ) {
 	ns := newTestStrain("pennypacker@penny.com", General)
 	ns.Data.Attributes.Parent = id
-	m2, err := repo.AddStrain(ns)
+	m2, err := repo.AddStrain(context.Background(), ns)
 	assert.NoErrorf(err, "expect no error, received %s", err)
 	assert.Equal(
 		m2.StrainProperties.Parent,
@@ -846,10 +842,10 @@ func TestEditStrain(t *testing.T) {
 	assert, repo := setUp(t)
 	defer tearDown(repo)
 	ns := newUpdatableTestStrain("todd@gagg.com", General)
-	m, err := repo.AddStrain(ns)
+	m, err := repo.AddStrain(context.Background(), ns)
 	assert.NoErrorf(err, "expect no error, received %s", err)
 	us := strainUpdateInstance(ns, m)
-	um, err := repo.EditStrain(us)
+	um, err := repo.EditStrain(context.Background(), us, "")
 	assert.NoErrorf(err, "expect no error, received %s", err)
 	assert.Equal(um.StockID, m.StockID, "should match the stock id")
 	assert.Equal(
@@ -913,10 +909,10 @@ func TestEditStrainWithParent(t *testing.T) {
 	t.Parallel()
 	assert, repo := setUp(t)
 	defer tearDown(repo)
-	pm, err := repo.AddStrain(newTestParentStrain("tim@watley.org"))
+	pm, err := repo.AddStrain(context.Background(), newTestParentStrain("tim@watley.org"))
 	assert.NoErrorf(err, "expect no error, received %s", err)
 	ns := newUpdatableTestStrain("todd@gagg.com", General)
-	ust, err := repo.AddStrain(ns)
+	ust, err := repo.AddStrain(context.Background(), ns)
 	assert.NoErrorf(err, "expect no error, received %s", err)
 	us2 := &stock.StrainUpdate{
 		Data: &stock.StrainUpdate_Data{
@@ -930,7 +926,7 @@ func TestEditStrainWithParent(t *testing.T) {
 			},
 		},
 	}
-	um2, err := repo.EditStrain(us2)
+	um2, err := repo.EditStrain(context.Background(), us2, "")
 	assert.NoErrorf(err, "expect no error, received %s", err)
 	assert.Equal(um2.StockID, ust.StockID, "should match their id")
 	assert.Equal(
@@ -997,6 +993,7 @@ func testMoreEditWithParent(
 	// add another new strain, let's make this one a parent
 	// so we can test updating parent if one already exists
 	pu, err := repo.AddStrain(
+		context.Background(),
 		newUpdatableTestStrain("castle@vania.org", General),
 	)
 	assert.NoErrorf(err, "expect no error, received %s", err)
@@ -1010,7 +1007,7 @@ func testMoreEditWithParent(
 			},
 		},
 	}
-	um3, err := repo.EditStrain(us3)
+	um3, err := repo.EditStrain(context.Background(), us3, "")
 	assert.NoErrorf(err, "expect no error, received %s", err)
 	assert.Equal(
 		um3.StrainProperties.Parent,
@@ -1028,3 +1025,31 @@ func testMoreEditWithParent(
 func stockToID(model *model.StockDoc) string {
 	return model.StockID
 }
+
+func TestCountStrains(t *testing.T) {
+	t.Parallel()
+	assert, repo := setUp(t)
+	defer tearDown(repo)
+	err := createTestStrains(12, General, repo)
+	assert.NoError(err, "expect no error from creating strains")
+	n, err := repo.CountStrains(context.Background(), "")
+	assert.NoError(err, "expect no error from counting strains")
+	assert.Equal(int64(12), n, "should count all strains")
+	n, err = repo.CountStrains(context.Background(), filterRegularStrain)
+	assert.NoError(err, "expect no error from counting with filter")
+	assert.Equal(int64(12), n, "should count all general strains")
+	n, err = repo.CountStrains(context.Background(), filterGwdiStrain)
+	assert.NoError(err, "expect no error from counting with filter")
+	assert.Equal(int64(0), n, "should count no REMI-seq strains")
+	ls, err := repo.ListStrains(
+		context.Background(),
+		&model.ListParams{Limit: 4, Filter: filterOne},
+	)
+	assert.NoError(err, "expect no error in listing strains")
+	assert.Len(ls, 5, "should match the provided limit number + 1")
+	n, err = repo.CountStrains(context.Background(), filterOne)
+	assert.NoError(err, "expect no error from counting with filter")
+	assert.Equal(int64(12), n, "should count beyond the listed page")
+	_, err = repo.CountStrains(context.Background(), filterBad)
+	assert.Error(err, "expect error from counting with bad filter")
+}
@@ -7,15 +7,23 @@ import (
 	"github.com/cockroachdb/errors"
 	"github.com/dictyBase/go-genproto/dictybaseapis/stock"
 	"github.com/dictyBase/modware-stock/internal/model"
+	"github.com/dictyBase/modware-stock/internal/repository"
 	"github.com/dictyBase/modware-stock/internal/repository/arangodb/statement"
 )
 
 // AddStrain creates a new strain stock
 func (ar *arangorepository) AddStrain(
+	ctx context.Context,
 	ns *stock.NewStrain,
 ) (*model.StockDoc, error) {
-	return ar.persistStrain(&persistStrainParams{
-		parent:          ns.Data.Attributes.Parent,
+	parents, err := model.ParseParents(ns.Data.Attributes.Parent)
+	if err != nil {
+		return &model.StockDoc{}, err
+	}
+	return ar.persistStrain(ctx, &persistStrainParams{
+		action:          model.RevisionCreate,
+		actor:           ns.Data.Attributes.CreatedBy,
+		parents:         parents,
 		dictyStrainProp: ns.Data.Attributes.DictyStrainProperty,
 		statement:       statement.StockStrainIns,
 		parentStatement: statement.StockStrainWithParentsIns,
@@ -29,63 +37,96 @@ func (ar *arangorepository) AddStrain(
 	})
 }
 
-// EditStrain updates an existing strain
+// EditStrain updates an existing strain. The parents of the strain are
+// replaced by the ones given, or removed by model.NoParent. A non-empty
+// rev is the combined revision the strain is expected to be at, the update
+// fails with repository.ErrRevisionMismatch if it was modified since.
 func (ar *arangorepository) EditStrain(
+	ctx context.Context,
 	us *stock.StrainUpdate,
+	rev string,
 ) (*model.StockDoc, error) {
 	m := &model.StockDoc{}
-	propKey, err := ar.checkStock(us.Data.Id)
+	parents, replace, err := model.ParseParentUpdate(us.Data.Attributes.Parent)
 	if err != nil {
 		return m, err
 	}
-	bindVars := getUpdatableStrainBindParams(us.Data.Attributes)
-	bindStVars := getUpdatableStrainPropBindParams(us.Data.Attributes)
-	cmBindVars := mergeBindParams(
-		map[string]interface{}{
-			"@stock_properties_collection": ar.stockc.stockProp.Name(),
-			"@stock_collection":            ar.stockc.stock.Name(),
-			"key":                          us.Data.Id,
-			"propkey":                      propKey,
-		},
-		bindVars, bindStVars,
-	)
-	parent := us.Data.Attributes.Parent
-	stmt := statement.StrainUpd
-	if len(parent) > 0 { // in case parent is present
-		pVars, pStmt, err := ar.handleEditStrainWithParent(parent, us.Data.Id)
+	run := ar.withTransaction
+	if len(parents) > 0 {
+		run = ar.withParentLock
+	}
+	err = run(ctx, func(tx *dbTx) error {
+		propKey, err := ar.checkStock(tx, us.Data.Id)
 		if err != nil {
-			return m, err
+			return err
 		}
-		stmt = pStmt
-		cmBindVars = mergeBindParams(cmBindVars, pVars)
-		m.StrainProperties = &model.StrainProperties{Parent: parent}
-	}
-	rupd, err := ar.database.DoRun(
-		fmt.Sprintf(
-			stmt,
-			genAQLDocExpression(bindVars),
-			genAQLDocExpression(bindStVars),
-		),
-		cmBindVars,
-	)
-	if err != nil {
-		return m, errors.Errorf(
-			"error in editing strain %s %s",
-			us.Data.Id, err,
+		rVars, err := ar.revisionBindParams(tx, us.Data.Id, propKey, rev)
+		if err != nil {
+			return err
+		}
+		before, err := ar.stockSnapshot(tx, us.Data.Id)
+		if err != nil {
+			return err
+		}
+		bindVars := getUpdatableStrainBindParams(us.Data.Attributes)
+		bindStVars := getUpdatableStrainPropBindParams(us.Data.Attributes)
+		cmBindVars := mergeBindParams(
+			map[string]interface{}{
+				"@stock_properties_collection": ar.stockc.stockProp.Name(),
+				"@stock_collection":            ar.stockc.stock.Name(),
+				"key":                          us.Data.Id,
+				"propkey":                      propKey,
+			},
+			bindVars, bindStVars, rVars,
 		)
-	}
-	err = rupd.Read(m)
+		stmt := statement.StrainUpd
+		if replace { // the parents are either replaced or removed
+			pVars, err := ar.replaceParents(tx, us.Data.Id, parents)
+			if err != nil {
+				return err
+			}
+			stmt = statement.StrainWithParentsUpd
+			cmBindVars = mergeBindParams(cmBindVars, pVars)
+			m.StrainProperties = &model.StrainProperties{Parents: parents}
+			if len(parents) > 0 {
+				m.StrainProperties.Parent = parents[0].ID
+			}
+		}
+		_, err = tx.getRow(
+			fmt.Sprintf(
+				stmt,
+				genAQLDocExpression(bindVars),
+				genAQLDocExpression(bindStVars),
+			),
+			cmBindVars, m,
+		)
+		if err != nil {
+			return updateError(us.Data.Id, rev, err)
+		}
+		return ar.addRevision(
+			tx, us.Data.Id, model.RevisionUpdate,
+			us.Data.Attributes.UpdatedBy, before,
+		)
+	})
 	return m, err
 }
 
 // LoadStrain will insert existing strain data into the database.
 // It receives the already existing strain ID and the data to go with it.
 func (ar *arangorepository) LoadStrain(
+	ctx context.Context,
 	id string,
 	es *stock.ExistingStrain,
 ) (*model.StockDoc, error) {
-	return ar.persistStrain(&persistStrainParams{
-		parent:          es.Data.Attributes.Parent,
+	parents, err := model.ParseParents(es.Data.Attributes.Parent)
+	if err != nil {
+		return &model.StockDoc{}, err
+	}
+	return ar.persistStrain(ctx, &persistStrainParams{
+		id:              id,
+		action:          model.RevisionLoad,
+		actor:           es.Data.Attributes.CreatedBy,
+		parents:         parents,
 		dictyStrainProp: es.Data.Attributes.DictyStrainProperty,
 		statement:       statement.StockStrainLoad,
 		parentStatement: statement.StockStrainWithParentLoad,
@@ -185,85 +226,84 @@ func addableStrainBindParams(
 	}
 }
 
-func (ar *arangorepository) handleEditStrainWithParent(
-	parent, id string,
-) (map[string]interface{}, string, error) {
-	pVar := map[string]interface{}{
-		"parent_graph": ar.stockc.strain2Parent.Name(),
-		"strain_key":   id,
-	}
-	if err := ar.validateParent(parent); err != nil {
-		return pVar, "", err
-	}
-	r, err := ar.database.GetRow(statement.StrainGetParentRel, pVar)
+// replaceParents removes the parent edges of a strain and gives the bind
+// parameters for adding the edges of its new parents
+func (ar *arangorepository) replaceParents(
+	tx *dbTx,
+	id string,
+	parents []*model.StrainParent,
+) (map[string]interface{}, error) {
+	pVars, err := ar.parentBindParams(tx, id, parents)
 	if err != nil {
-		return pVar,
-			"",
-			errors.Errorf("error in parent relation query %s", err)
-	}
-	var pKey string
-	if !r.IsEmpty() {
-		if err := r.Read(&pKey); err != nil {
-			return pVar,
-				"",
-				errors.Errorf("error in reading parent relation key %s", err)
-		}
-	}
-	stmt := statement.StrainWithNewParentUpd
-	cmBindVars := map[string]interface{}{
-		"parent":                    parent,
-		"stock_collection":          ar.stockc.stock.Name(),
-		"@parent_strain_collection": ar.stockc.parentStrain.Name(),
-	}
-	if len(pKey) > 0 {
-		stmt = statement.StrainWithExistingParentUpd
-		cmBindVars["pkey"] = pKey
+		return pVars, err
 	}
-	return cmBindVars, stmt, nil
-}
-
-func (ar *arangorepository) validateParent(parent string) error {
-	ok, err := ar.stockc.stock.DocumentExists(context.Background(), parent)
+	err = tx.do(
+		statement.StrainParentsRemove,
+		map[string]interface{}{
+			"key":                       id,
+			"stock_collection":          ar.stockc.stock.Name(),
+			"@parent_strain_collection": ar.stockc.parentStrain.Name(),
+		})
 	if err != nil {
-		return errors.Errorf(
-			"error in checking for parent id %s %s",
-			parent,
-			err,
+		return pVars, errors.Errorf(
+			"error in removing parents of strain %s %s", id, err,
 		)
 	}
-	if !ok {
-		return errors.Errorf("parent id %s does not exist in database", parent)
-	}
-	return nil
+	pVars["stock_collection"] = ar.stockc.stock.Name()
+	return pVars, nil
 }
 
-func (ar *arangorepository) handleAddStrainWithParent(
-	parent string,
+// parentBindParams gives the parents of a strain to bind to the statements
+// that add the parent edges, every parent has to be an existing stock. The
+// id of the strain is empty for a strain that is yet to be created, the
+// parents of any other strain are checked for cycles.
+func (ar *arangorepository) parentBindParams(
+	tx *dbTx,
+	id string,
+	parents []*model.StrainParent,
 ) (map[string]interface{}, error) {
-	qVar := map[string]interface{}{
-		"@stock_collection": ar.stockc.stock.Name(),
-		"id":                parent,
-	}
-	r, err := ar.database.GetRow(statement.StockFindQ, qVar)
-	if err != nil {
-		return qVar,
-			errors.Errorf("error in searching for parent %s %s", parent, err)
-	}
-	if r.IsEmpty() {
-		return qVar, errors.Errorf("parent %s is not found", parent)
-	}
-	var pid string
-	if err := r.Read(&pid); err != nil {
-		return qVar,
-			errors.Errorf("error in scanning the value %s %s", parent, err)
+	pl := make([]map[string]interface{}, 0, len(parents))
+	for _, p := range parents {
+		if p.ID == id {
+			return nil, errors.Wrapf(
+				repository.ErrParentCycle,
+				"strain %s could not be its own parent, cycle %s",
+				id, formatCycle([]string{id, id}),
+			)
+		}
+		var pid string
+		found, err := tx.getRow(
+			statement.StockFindQ,
+			map[string]interface{}{
+				"@stock_collection": ar.stockc.stock.Name(),
+				"id":                p.ID,
+			}, &pid)
+		if err != nil {
+			return nil, errors.Errorf(
+				"error in searching for parent %s %s", p.ID, err,
+			)
+		}
+		if !found {
+			return nil, errors.Errorf("parent %s is not found", p.ID)
+		}
+		if len(id) > 0 {
+			if err := ar.checkParentCycle(tx, id, p.ID, pid); err != nil {
+				return nil, err
+			}
+		}
+		pl = append(pl, map[string]interface{}{
+			"from":         pid,
+			"relationship": p.Relationship,
+		})
 	}
 	return map[string]interface{}{
-		"pid":                       pid,
+		"parents":                   pl,
 		"@parent_strain_collection": ar.stockc.parentStrain.Name(),
 	}, nil
 }
 
 func (ar *arangorepository) persistStrain(
+	ctx context.Context,
 	args *persistStrainParams,
 ) (*model.StockDoc, error) {
 	m := &model.StockDoc{
@@ -271,27 +311,33 @@ func (ar *arangorepository) persistStrain(
 			DictyStrainProperty: args.dictyStrainProp,
 		},
 	}
-	tid, err := ar.termID(args.dictyStrainProp, ar.strainOnto)
-	if err != nil {
-		return m, err
+	run := ar.withTransaction
+	if len(args.parents) > 0 {
+		run = ar.withParentLock
 	}
-	stmt := args.statement
-	bindVars := mergeBindParams(map[string]interface{}{
-		"to": tid,
-	}, args.bindVars)
-	if len(args.parent) > 0 { // parent is present
-		pVars, err := ar.handleAddStrainWithParent(args.parent)
+	err := run(ctx, func(tx *dbTx) error {
+		tid, err := ar.termID(tx, args.dictyStrainProp, ar.strainOnto)
 		if err != nil {
-			return m, err
+			return err
 		}
-		bindVars = mergeBindParams(bindVars, pVars)
-		m.StrainProperties.Parent = args.parent
-		stmt = args.parentStatement
-	}
-	r, err := ar.database.DoRun(stmt, bindVars)
-	if err != nil {
-		return m, err
-	}
-	err = r.Read(m)
+		stmt := args.statement
+		bindVars := mergeBindParams(map[string]interface{}{
+			"to": tid,
+		}, args.bindVars)
+		if len(args.parents) > 0 { // parents are present
+			pVars, err := ar.parentBindParams(tx, args.id, args.parents)
+			if err != nil {
+				return err
+			}
+			bindVars = mergeBindParams(bindVars, pVars)
+			m.StrainProperties.Parent = args.parents[0].ID
+			m.StrainProperties.Parents = args.parents
+			stmt = args.parentStatement
+		}
+		if _, err := tx.getRow(stmt, bindVars, m); err != nil {
+			return err
+		}
+		return ar.addRevision(tx, m.Key, args.action, args.actor, nil)
+	})
 	return m, err
 }
@@ -0,0 +1,61 @@
+package arangodb
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cockroachdb/errors"
+	"github.com/dictyBase/modware-stock/internal/model"
+	"github.com/dictyBase/modware-stock/internal/repository/arangodb/statement"
+)
+
+var changePage = pageQuery{
+	vars: map[string]bool{"c": true},
+	key:  "c.key",
+}
+
+// ListStockChanges lists the strains and plasmids that changed since the
+// watermark or the cursor, the earliest change first. The stocks that were
+// removed or purged are given as tombstones.
+func (ar *arangorepository) ListStockChanges(
+	ctx context.Context,
+	p *model.ChangeParams,
+) ([]*model.StockChange, error) {
+	cursor := p.Cursor
+	if cursor == nil {
+		cursor = &model.StockCursor{
+			Values: []interface{}{p.Since.UnixMilli()},
+		}
+	}
+	bindVars := map[string]interface{}{
+		"ontology":                   ar.strainOnto,
+		"parent_graph":               ar.stockc.strain2Parent.Name(),
+		"stock_prop_graph":           ar.stockc.stockPropType.Name(),
+		"stock_cvterm_graph":         ar.stockc.stockOnto.Name(),
+		"@stock_collection":          ar.stockc.stock.Name(),
+		"@cv_collection":             ar.ontoc.Cv.Name(),
+		"@stock_revision_collection": ar.stockc.stockRevision.Name(),
+		"limit":                      p.Limit + 1,
+	}
+	cond, err := changePage.cursorCondition(
+		cursor,
+		[]string{"sort_values[0]"},
+		[]string{">"},
+		bindVars,
+	)
+	if err != nil {
+		return []*model.StockChange{}, err
+	}
+	// the changes before the cursor are left out early, before the
+	// stocks are traversed
+	bindVars["since"] = cursor.Values[0]
+	changes, err := searchRows[model.StockChange](
+		ar.directTx(ctx),
+		fmt.Sprintf(statement.StockChangeList, "FILTER "+cond),
+		bindVars,
+	)
+	if err != nil {
+		return changes, errors.Errorf("error in listing changed stocks %s", err)
+	}
+	return changes, nil
+}
@@ -0,0 +1,84 @@
+package arangodb
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dictyBase/modware-stock/internal/model"
+)
+
+func TestListStockChanges(t *testing.T) {
+	t.Parallel()
+	assert, repo := setUp(t)
+	defer tearDown(repo)
+	ns := newUpdatableTestStrain("todd@gagg.com", General)
+	um, err := repo.AddStrain(context.Background(), ns)
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	ids, err := createTestStrainsWithIDs(2, General, repo)
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	pm, err := repo.AddPlasmid(context.Background(), newTestPlasmid("george@costanza.com"))
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	all, err := repo.ListStockChanges(
+		context.Background(), &model.ChangeParams{Limit: 10},
+	)
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	assert.Len(all, 4, "should list every stock without a watermark")
+	time.Sleep(500 * time.Millisecond)
+	watermark := time.Now()
+	time.Sleep(500 * time.Millisecond)
+	_, err = repo.EditStrain(context.Background(), strainUpdateInstance(ns, um), "")
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	time.Sleep(10 * time.Millisecond)
+	err = repo.RemoveStock(context.Background(), pm.Key, "art@vandelay.com")
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	time.Sleep(10 * time.Millisecond)
+	err = repo.RemoveStock(context.Background(), ids[0], "art@vandelay.com")
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	err = repo.PurgeStock(context.Background(), ids[0], "art@vandelay.com", false)
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	changes, err := repo.ListStockChanges(
+		context.Background(), &model.ChangeParams{Since: watermark, Limit: 10},
+	)
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	assert.Len(changes, 3, "should only list the stocks changed after the watermark")
+	assert.Equal(um.Key, changes[0].Key, "should list the earliest change first")
+	assert.False(changes[0].Deleted, "updated strain should not be a tombstone")
+	assert.Equal("strain", changes[0].Type, "should match the stock type")
+	assert.NotNil(changes[0].Stock, "updated strain should have its document")
+	assert.Equal(
+		"kirby@snes.org", changes[0].Stock.UpdatedBy,
+		"should match the user who updated the strain",
+	)
+	assert.Equal(pm.Key, changes[1].Key, "should list the removed plasmid")
+	assert.True(changes[1].Deleted, "removed plasmid should be a tombstone")
+	assert.Equal("plasmid", changes[1].Type, "should match the stock type")
+	assert.Nil(changes[1].Stock, "tombstone should not have any document")
+	assert.Equal(ids[0], changes[2].Key, "should list the purged strain")
+	assert.True(changes[2].Deleted, "purged strain should be a tombstone")
+	assert.Equal("strain", changes[2].Type, "should match the stock type")
+	for i := 1; i < len(changes); i++ {
+		assert.False(
+			changes[i].ChangedAt.Before(changes[i-1].ChangedAt),
+			"should order the changes by their time",
+		)
+	}
+	first, err := repo.ListStockChanges(
+		context.Background(), &model.ChangeParams{Since: watermark, Limit: 1},
+	)
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	assert.Len(first, 2, "should fetch one extra change to tell about more")
+	rest, err := repo.ListStockChanges(
+		context.Background(),
+		&model.ChangeParams{
+			Cursor: &model.StockCursor{
+				Values: first[0].SortValues,
+				Key:    first[0].Key,
+			},
+			Limit: 10,
+		},
+	)
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	assert.Len(rest, 2, "should resume after the cursor")
+	assert.Equal(pm.Key, rest[0].Key, "should not repeat the change at the cursor")
+}
@@ -0,0 +1,188 @@
+package arangodb
+
+import (
+	"context"
+	"time"
+
+	driver "github.com/arangodb/go-driver"
+	"github.com/cockroachdb/errors"
+	"github.com/dictyBase/modware-stock/internal/repository"
+)
+
+const defaultLockTimeout = 30 * time.Second
+
+// dbTx runs queries within an arangodb stream transaction, or directly
+// against the database in absence of any transaction
+type dbTx struct {
+	ctx context.Context
+	dbh driver.Database
+	// conflict is set when a query of the transaction failed with a
+	// write-write conflict
+	conflict bool
+}
+
+// directTx gives a dbTx without any transaction, the queries are run
+// independently of each other
+func (ar *arangorepository) directTx(ctx context.Context) *dbTx {
+	return &dbTx{ctx: ctx, dbh: ar.database.Handler()}
+}
+
+// withTransaction runs fn within a stream transaction that covers all the
+// stock collections. The collections are write locked, which keeps the
+// transactions from writing the same document, the later one fails with a
+// write-write conflict reported as repository.ErrRevisionMismatch. It
+// does not keep a document that fn only reads from being changed before
+// the commit, the transactions that depend on such a read run through
+// withParentLock instead. The transaction is committed if fn succeeds and
+// aborted otherwise, including when ctx is done before the commit.
+func (ar *arangorepository) withTransaction(
+	ctx context.Context,
+	fn func(tx *dbTx) error,
+) error {
+	return ar.runTransaction(ctx, false, fn)
+}
+
+// withParentLock runs fn as withTransaction does, with the stock and the
+// parent_strain collections locked exclusively for the transactions that
+// add parent edges. A parent is only read when it is validated, the lock
+// keeps it from being removed or purged before its edge is added. It also
+// runs the parent assignments one after the other, so that every check of
+// a cycle sees the edges of the assignments before it.
+func (ar *arangorepository) withParentLock(
+	ctx context.Context,
+	fn func(tx *dbTx) error,
+) error {
+	return ar.runTransaction(ctx, true, fn)
+}
+
+func (ar *arangorepository) runTransaction(
+	ctx context.Context,
+	exclusive bool,
+	fn func(tx *dbTx) error,
+) error {
+	cols := driver.TransactionCollections{
+		Write: []string{
+			ar.stockc.stockProp.Name(),
+			ar.stockc.stockKey.Name(),
+			ar.stockc.stockRevision.Name(),
+			ar.stockc.stockType.Name(),
+			ar.stockc.stockTerm.Name(),
+		},
+	}
+	locked := []string{ar.stockc.stock.Name(), ar.stockc.parentStrain.Name()}
+	if exclusive {
+		cols.Exclusive = locked
+	} else {
+		cols.Write = append(cols.Write, locked...)
+	}
+	dbh := ar.database.Handler()
+	tid, err := dbh.BeginTransaction(
+		ctx, cols,
+		&driver.BeginTransactionOptions{LockTimeout: ar.lockTimeout},
+	)
+	if err != nil {
+		return errors.Errorf("error in beginning transaction %s", err)
+	}
+	tx := &dbTx{ctx: driver.WithTransactionID(ctx, tid), dbh: dbh}
+	err = fn(tx)
+	if err == nil {
+		err = ctx.Err()
+	}
+	if err != nil && tx.conflict &&
+		!errors.Is(err, repository.ErrRevisionMismatch) {
+		err = errors.Wrapf(
+			repository.ErrRevisionMismatch,
+			"stock was modified concurrently %s", err,
+		)
+	}
+	if err != nil {
+		// the abort has to go through even if ctx is already done
+		aerr := dbh.AbortTransaction(context.Background(), tid, nil)
+		if aerr != nil {
+			return errors.Errorf(
+				"error in aborting transaction %s after failure %s",
+				aerr, err,
+			)
+		}
+		return err
+	}
+	if err := dbh.CommitTransaction(ctx, tid, nil); err != nil {
+		return errors.Errorf("error in committing transaction %s", err)
+	}
+	return nil
+}
+
+// query runs a query and notes whether it failed with a write-write
+// conflict. A query is given the time left before the deadline of the
+// context as its maximum runtime, so that the server kills it instead of
+// running it on after the caller has given up.
+func (tx *dbTx) query(
+	query string,
+	bindVars map[string]interface{},
+) (driver.Cursor, error) {
+	ctx := tx.ctx
+	if deadline, ok := ctx.Deadline(); ok {
+		left := time.Until(deadline)
+		if left <= 0 {
+			return nil, context.DeadlineExceeded
+		}
+		ctx = driver.WithQueryMaxRuntime(ctx, left.Seconds())
+	}
+	c, err := tx.dbh.Query(ctx, query, bindVars)
+	if err != nil && driver.IsConflict(err) {
+		tx.conflict = true
+	}
+	return c, err
+}
+
+// getRow runs a query that is expected to return a single row and reads it
+// into v. It returns false if the query gives no result.
+func (tx *dbTx) getRow(
+	query string,
+	bindVars map[string]interface{},
+	v interface{},
+) (bool, error) {
+	c, err := tx.query(query, bindVars)
+	if err != nil {
+		return false, err
+	}
+	defer c.Close()
+	if !c.HasMore() {
+		return false, nil
+	}
+	if _, err := c.ReadDocument(tx.ctx, v); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// do runs a data modification query that returns no result
+func (tx *dbTx) do(query string, bindVars map[string]interface{}) error {
+	c, err := tx.query(query, bindVars)
+	if err != nil {
+		return err
+	}
+	return c.Close()
+}
+
+// searchRows runs a query and reads all of its rows
+func searchRows[T any](
+	tx *dbTx,
+	query string,
+	bindVars map[string]interface{},
+) ([]*T, error) {
+	rows := make([]*T, 0)
+	c, err := tx.query(query, bindVars)
+	if err != nil {
+		return rows, err
+	}
+	defer c.Close()
+	for c.HasMore() {
+		row := new(T)
+		if _, err := c.ReadDocument(tx.ctx, row); err != nil {
+			return rows, err
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
@@ -0,0 +1,150 @@
+package arangodb
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/dictyBase/modware-stock/internal/model"
+	"github.com/dictyBase/modware-stock/internal/repository"
+	"github.com/dictyBase/modware-stock/internal/repository/arangodb/statement"
+)
+
+func TestWithTransaction(t *testing.T) {
+	t.Parallel()
+	assert, repo := setUp(t)
+	defer tearDown(repo)
+	ar, ok := repo.(*arangorepository)
+	assert.True(ok, "should be an arangodb repository")
+	m, err := repo.AddStrain(context.Background(), newTestStrain("todd@gagg.com", General))
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	softRemove := func(tx *dbTx) error {
+		return tx.do(
+			statement.StockSoftRemove,
+			map[string]interface{}{
+				"key":               m.Key,
+				"deleted_by":        "todd@gagg.com",
+				"@stock_collection": ar.stockc.stock.Name(),
+			})
+	}
+	err = ar.withTransaction(context.Background(), func(tx *dbTx) error {
+		if err := softRemove(tx); err != nil {
+			return err
+		}
+		return errors.New("failure after write")
+	})
+	assert.Error(err, "should return the error of the failed step")
+	g, err := repo.GetStrain(context.Background(), m.Key)
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	assert.False(g.NotFound, "should abort the removal")
+	err = ar.withTransaction(context.Background(), softRemove)
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	g, err = repo.GetStrain(context.Background(), m.Key)
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	assert.True(g.NotFound, "should commit the removal")
+}
+
+func TestConcurrentTransactions(t *testing.T) {
+	t.Parallel()
+	assert, repo := setUp(t)
+	defer tearDown(repo)
+	ar, ok := repo.(*arangorepository)
+	assert.True(ok, "should be an arangodb repository")
+	m, err := repo.AddStrain(context.Background(), newTestStrain("todd@gagg.com", General))
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	other, err := repo.AddStrain(context.Background(), newTestStrain("todd@gagg.com", General))
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	softRemove := func(key string) func(tx *dbTx) error {
+		return func(tx *dbTx) error {
+			return tx.do(
+				statement.StockSoftRemove,
+				map[string]interface{}{
+					"key":               key,
+					"deleted_by":        "todd@gagg.com",
+					"@stock_collection": ar.stockc.stock.Name(),
+				})
+		}
+	}
+	var inner, same error
+	err = ar.withTransaction(context.Background(), func(tx *dbTx) error {
+		if err := softRemove(m.Key)(tx); err != nil {
+			return err
+		}
+		inner = ar.withTransaction(context.Background(), softRemove(other.Key))
+		same = ar.withTransaction(context.Background(), softRemove(m.Key))
+		return nil
+	})
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	assert.NoErrorf(inner, "should write another stock alongside, received %s", inner)
+	assert.True(
+		errors.Is(same, repository.ErrRevisionMismatch),
+		"expect revision mismatch from writing the same stock",
+	)
+}
+
+func TestParentLock(t *testing.T) {
+	t.Parallel()
+	assert, repo := setUp(t)
+	defer tearDown(repo)
+	ar, ok := repo.(*arangorepository)
+	assert.True(ok, "should be an arangodb repository")
+	ar.lockTimeout = time.Second
+	pm, err := repo.AddStrain(context.Background(), newTestStrain("todd@gagg.com", General))
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	var removal error
+	err = ar.withParentLock(context.Background(), func(tx *dbTx) error {
+		_, err := ar.parentBindParams(
+			tx, "",
+			[]*model.StrainParent{{ID: pm.Key, Relationship: model.SelectedFrom}},
+		)
+		if err != nil {
+			return err
+		}
+		removal = repo.RemoveStock(context.Background(), pm.Key, "todd@gagg.com")
+		return nil
+	})
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	assert.Error(removal, "should not remove the parent while it is validated")
+	g, err := repo.GetStrain(context.Background(), pm.Key)
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	assert.False(g.NotFound, "should keep the parent")
+}
+
+func TestAddStrainWithAbsentParent(t *testing.T) {
+	t.Parallel()
+	assert, repo := setUp(t)
+	defer tearDown(repo)
+	ns := newTestStrain("todd@gagg.com", General)
+	ns.Data.Attributes.Parent = "DBS0000001"
+	_, err := repo.AddStrain(context.Background(), ns)
+	assert.Error(err, "should not add strain with absent parent")
+	ms, err := repo.ListStrains(context.Background(), &model.ListParams{Limit: 10})
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	assert.Empty(ms, "should not leave any partial strain")
+}
+
+func TestCancelledContext(t *testing.T) {
+	t.Parallel()
+	assert, repo := setUp(t)
+	defer tearDown(repo)
+	m, err := repo.AddStrain(
+		context.Background(),
+		newTestStrain("todd@gagg.com", General),
+	)
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = repo.GetStrain(ctx, m.Key)
+	assert.Error(err, "should not run query with cancelled context")
+	_, err = repo.ListStrains(ctx, &model.ListParams{Limit: 10})
+	assert.Error(err, "should not run query with cancelled context")
+	_, err = repo.AddStrain(ctx, newTestStrain("todd@gagg.com", General))
+	assert.Error(err, "should not write with cancelled context")
+	ms, err := repo.ListStrains(
+		context.Background(),
+		&model.ListParams{Limit: 10},
+	)
+	assert.NoErrorf(err, "expect no error, received %s", err)
+	assert.Len(ms, 1, "should not add strain with cancelled context")
+}
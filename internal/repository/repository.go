@@ -1,7 +1,10 @@
 package repository
 
 import (
+	"context"
+	"errors"
 	"io"
+	"time"
 
 	manager "github.com/dictyBase/arangomanager"
 	"github.com/dictyBase/go-genproto/dictybaseapis/stock"
@@ -9,20 +12,176 @@ import (
 	"github.com/dictyBase/modware-stock/internal/model"
 )
 
+// ErrStockInUse is returned when a stock could not be removed because
+// other strains still refer to it
+var ErrStockInUse = errors.New("stock is referenced by other strains")
+
+// ErrRevisionMismatch is returned when a stock was modified after the
+// revision expected by an update
+var ErrRevisionMismatch = errors.New("stock revision does not match")
+
+// ErrInvalidFilter is returned when a list filter could not be parsed or
+// refers to an unsupported field
+var ErrInvalidFilter = errors.New("invalid filter")
+
+// ErrInvalidSort is returned when a list is sorted by an unsupported field
+// or paged with a cursor from a different sort order
+var ErrInvalidSort = errors.New("invalid sort")
+
+// ErrInvalidFacet is returned when the facets of a list are requested
+// for an unsupported field
+var ErrInvalidFacet = errors.New("invalid facet")
+
+// ErrStockNotFound is returned when an operation starts from a stock that
+// does not exist
+var ErrStockNotFound = errors.New("stock not found")
+
+// ErrStockNotDeleted is returned when a stock that is not removed is
+// purged
+var ErrStockNotDeleted = errors.New("stock not deleted")
+
+// ErrInvalidField is returned when a response is limited to an unsupported
+// field
+var ErrInvalidField = errors.New("invalid field")
+
+// ErrParentCycle is returned when a strain would become its own ancestor
+// through the parents assigned to it
+var ErrParentCycle = errors.New("parent makes a cycle")
+
 // StockRepository is an interface for managing stock information
 type StockRepository interface {
-	GetStrain(id string) (*model.StockDoc, error)
-	GetPlasmid(id string) (*model.StockDoc, error)
-	AddStrain(ns *stock.NewStrain) (*model.StockDoc, error)
-	AddPlasmid(ns *stock.NewPlasmid) (*model.StockDoc, error)
-	EditStrain(us *stock.StrainUpdate) (*model.StockDoc, error)
-	EditPlasmid(us *stock.PlasmidUpdate) (*model.StockDoc, error)
-	ListStrains(s *stock.StockParameters) ([]*model.StockDoc, error)
-	ListStrainsByIds(s *stock.StockIdList) ([]*model.StockDoc, error)
-	ListPlasmids(s *stock.StockParameters) ([]*model.StockDoc, error)
-	LoadStrain(id string, es *stock.ExistingStrain) (*model.StockDoc, error)
-	LoadPlasmid(id string, ep *stock.ExistingPlasmid) (*model.StockDoc, error)
-	RemoveStock(id string) error
+	GetStrain(
+		ctx context.Context,
+		id string,
+		fields ...string,
+	) (*model.StockDoc, error)
+	GetPlasmid(
+		ctx context.Context,
+		id string,
+		fields ...string,
+	) (*model.StockDoc, error)
+	GetStrainAsOf(
+		ctx context.Context,
+		id string,
+		asOf time.Time,
+	) (*model.StockDoc, error)
+	GetPlasmidAsOf(
+		ctx context.Context,
+		id string,
+		asOf time.Time,
+	) (*model.StockDoc, error)
+	AddStrain(
+		ctx context.Context,
+		ns *stock.NewStrain,
+	) (*model.StockDoc, error)
+	AddPlasmid(
+		ctx context.Context,
+		ns *stock.NewPlasmid,
+	) (*model.StockDoc, error)
+	EditStrain(
+		ctx context.Context,
+		us *stock.StrainUpdate,
+		rev string,
+	) (*model.StockDoc, error)
+	EditPlasmid(
+		ctx context.Context,
+		us *stock.PlasmidUpdate,
+		rev string,
+	) (*model.StockDoc, error)
+	ListStrains(
+		ctx context.Context,
+		p *model.ListParams,
+	) ([]*model.StockDoc, error)
+	ListStrainsByIds(
+		ctx context.Context,
+		s *stock.StockIdList,
+		fields ...string,
+	) ([]*model.StockDoc, error)
+	ListPlasmids(
+		ctx context.Context,
+		p *model.ListParams,
+	) ([]*model.StockDoc, error)
+	CountStrains(ctx context.Context, filter string) (int64, error)
+	ListFilterableFields(stockType string) ([]*model.FilterableField, error)
+	StrainFacets(
+		ctx context.Context,
+		filter string,
+		fields []string,
+	) ([]*model.Facet, error)
+	CountPlasmids(ctx context.Context, filter string) (int64, error)
+	ListStrainsAsOf(
+		ctx context.Context,
+		p *model.ListParams,
+		asOf time.Time,
+	) ([]*model.StockDoc, error)
+	CountStrainsAsOf(
+		ctx context.Context,
+		filter string,
+		asOf time.Time,
+	) (int64, error)
+	SearchStocks(
+		ctx context.Context,
+		p *model.SearchParams,
+	) ([]*model.StockSearchHit, error)
+	SuggestStocks(
+		ctx context.Context,
+		text string,
+		limit int64,
+	) ([]*model.StockSuggestion, error)
+	LoadStrain(
+		ctx context.Context,
+		id string,
+		es *stock.ExistingStrain,
+	) (*model.StockDoc, error)
+	LoadPlasmid(
+		ctx context.Context,
+		id string,
+		ep *stock.ExistingPlasmid,
+	) (*model.StockDoc, error)
+	RemoveStock(ctx context.Context, id, deletedBy string) error
+	RestoreStock(ctx context.Context, id, restoredBy string) error
+	ListDeletedStocks(
+		ctx context.Context,
+		cursor *model.StockCursor,
+		limit int64,
+	) ([]*model.StockDoc, error)
+	PurgeStock(
+		ctx context.Context,
+		id, purgedBy string,
+		cascade bool,
+	) error
+	PurgeDeletedStocks(
+		ctx context.Context,
+		before time.Time,
+		purgedBy string,
+	) ([]string, error)
+	BackfillRevisions(ctx context.Context, actor string) ([]string, error)
+	RevertStock(
+		ctx context.Context,
+		id, revision, revertedBy string,
+	) (*model.StockDoc, error)
+	ListStockRevisions(
+		ctx context.Context,
+		id string,
+		cursor *model.StockCursor,
+		limit int64,
+	) ([]*model.StockRevision, error)
+	ListStrainLineage(
+		ctx context.Context,
+		p *model.LineageParams,
+	) ([]*model.StrainRelative, error)
+	GetStrainLineageGraph(
+		ctx context.Context,
+		p *model.LineageParams,
+	) (*model.LineageGraph, error)
+	ListParentCycles(ctx context.Context) ([][]string, error)
+	ListStockChanges(
+		ctx context.Context,
+		p *model.ChangeParams,
+	) ([]*model.StockChange, error)
 	Dbh() *manager.Database
-	LoadOboJSON(r io.Reader) (*storage.UploadInformation, error)
+	LoadOboJSON(
+		ctx context.Context,
+		r io.Reader,
+	) (*storage.UploadInformation, error)
 }